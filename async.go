@@ -0,0 +1,217 @@
+package logerr
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what happens when an asynchronous logger's queue is full
+type OverflowPolicy int
+
+// Overflow policy constants
+const (
+	// OverflowBlock waits for room in the queue
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropNewest discards the message being logged
+	OverflowDropNewest
+
+	// OverflowDropOldest discards the oldest queued message to make room
+	OverflowDropOldest
+)
+
+// defaultQueueSize is used when EnableAsync is given a non-positive size
+const defaultQueueSize = 1024
+
+// asyncEntry is a queued record together with the logger snapshot that formats it
+type asyncEntry struct {
+	logger *Logger
+	record Record
+}
+
+// asyncQueue is a bounded queue of records drained by a background goroutine
+type asyncQueue struct {
+	entries chan asyncEntry
+	policy  OverflowPolicy
+
+	// closeMu guards closed and the closing of entries
+	closeMu sync.RWMutex
+	closed  bool
+	stopped chan struct{}
+
+	// mu and cond track queued and finished entries so Flush can wait on them
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queued   uint64
+	finished uint64
+
+	// dropped counts messages discarded since the last report
+	dropped atomic.Uint64
+	total   atomic.Uint64
+}
+
+// newAsyncQueue creates a queue and starts the goroutine that drains it
+func newAsyncQueue(size int, policy OverflowPolicy) *asyncQueue {
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	q := &asyncQueue{
+		entries: make(chan asyncEntry, size),
+		policy:  policy,
+		stopped: make(chan struct{}),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	go q.run()
+	return q
+}
+
+// run writes queued records until the queue is closed
+func (q *asyncQueue) run() {
+	defer close(q.stopped)
+	for e := range q.entries {
+		q.reportDropped(e.logger)
+		e.logger.write(e.record)
+		q.finish(1)
+	}
+}
+
+// enqueue adds a record to the queue according to the overflow policy
+// It returns false when the queue is closed and the caller should write directly
+func (q *asyncQueue) enqueue(l *Logger, r Record) bool {
+	q.closeMu.RLock()
+	defer q.closeMu.RUnlock()
+	if q.closed {
+		return false
+	}
+
+	e := asyncEntry{logger: l, record: r}
+	q.mu.Lock()
+	q.queued++
+	q.mu.Unlock()
+
+	switch q.policy {
+	case OverflowDropNewest:
+		select {
+		case q.entries <- e:
+		default:
+			q.drop()
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.entries <- e:
+				return true
+			default:
+			}
+			select {
+			case <-q.entries:
+				q.drop()
+			default:
+			}
+		}
+	default:
+		q.entries <- e
+	}
+	return true
+}
+
+// drop records a discarded message
+func (q *asyncQueue) drop() {
+	q.dropped.Add(1)
+	q.total.Add(1)
+	q.finish(1)
+}
+
+// finish marks n queued entries as written or dropped
+func (q *asyncQueue) finish(n uint64) {
+	q.mu.Lock()
+	q.finished += n
+	q.cond.Broadcast()
+	q.mu.Unlock()
+}
+
+// reportDropped writes a warning with the number of messages dropped since the last report
+func (q *asyncQueue) reportDropped(l *Logger) {
+	if n := q.dropped.Swap(0); n > 0 {
+		l.writeAll(Record{
+			Time:    time.Now(),
+			Level:   LogLevelWarn,
+			Message: fmt.Sprintf("dropped %d log messages", n),
+		}, true)
+	}
+}
+
+// flush waits until every record queued before the call has been written or dropped
+func (q *asyncQueue) flush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	target := q.queued
+	for q.finished < target {
+		q.cond.Wait()
+	}
+}
+
+// close flushes the queue and stops the background goroutine
+func (q *asyncQueue) close(l *Logger) {
+	q.closeMu.Lock()
+	if q.closed {
+		q.closeMu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.entries)
+	q.closeMu.Unlock()
+
+	<-q.stopped
+	q.reportDropped(l)
+}
+
+// EnableAsync makes the logger, and the loggers later derived from it, hand
+// records to a background goroutine through a queue of the given size.
+// policy decides what happens when the queue is full; dropped messages are
+// reported with a warning once the queue drains. Call Flush or Close before
+// the program exits to make sure queued messages are written
+func (l *Logger) EnableAsync(size int, policy OverflowPolicy) *Logger {
+	q := newAsyncQueue(size, policy)
+	var old *asyncQueue
+	l.update(func(l *Logger) {
+		old = l.async
+		l.async = q
+	})
+	if old != nil {
+		old.close(l.snapshot())
+	}
+	return l
+}
+
+// Flush writes any pending repeat summary, waits until all queued messages
+// have been written, and reports messages dropped from the queue or suppressed
+// by sampling or rate limiting that are not yet reported
+func (l *Logger) Flush() {
+	s := l.snapshot()
+	s.dedupe.flush()
+	if s.async != nil {
+		s.async.flush()
+		s.async.reportDropped(s)
+	}
+	s.reportSuppressed(s.limits.pending())
+}
+
+// Close flushes queued messages and stops the background goroutine
+// The logger keeps working afterwards, writing synchronously
+func (l *Logger) Close() {
+	s := l.snapshot()
+	if s.async != nil {
+		s.async.close(s)
+	}
+}
+
+// Dropped returns the total number of messages discarded because the queue was full
+func (l *Logger) Dropped() uint64 {
+	if q := l.snapshot().async; q != nil {
+		return q.total.Load()
+	}
+	return 0
+}
@@ -0,0 +1,162 @@
+package logerr
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// gateWriter blocks every Write until the gate is opened
+type gateWriter struct {
+	entered chan struct{}
+	gate    chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{entered: make(chan struct{}), gate: make(chan struct{})}
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.entered) })
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gateWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("async").EnableAsync(16, OverflowBlock)
+	logger.Output = &buf
+	logger.Level = LogLevelDebug
+
+	sub := logger.Add("sub")
+	for i := 0; i < 100; i++ {
+		sub.Infof("message %d", i)
+	}
+	logger.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("Expected 100 lines after Flush, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if line != fmt.Sprintf("[INF] async | sub | message %d", i) {
+			t.Fatalf("Expected messages in order, line %d was %q", i, line)
+		}
+	}
+	if logger.Dropped() != 0 {
+		t.Errorf("Expected no dropped messages with OverflowBlock, got %d", logger.Dropped())
+	}
+
+	// Test that the logger writes synchronously after Close
+	logger.Close()
+	buf.Reset()
+	logger.Error("after close")
+	if buf.String() != "[ERR] async | after close\n" {
+		t.Errorf("Expected synchronous write after Close, got %q", buf.String())
+	}
+	logger.Close()
+}
+
+func TestAsyncOverflow(t *testing.T) {
+	tests := []struct {
+		policy   OverflowPolicy
+		expected []string
+	}{
+		{OverflowDropNewest, []string{"message 0", "message 1", "message 2"}},
+		{OverflowDropOldest, []string{"message 0", "message 8", "message 9"}},
+	}
+
+	for _, test := range tests {
+		w := newGateWriter()
+		logger := DefaultLogger().EnableAsync(2, test.policy)
+		logger.Output = w
+
+		// Wait until the first message is stuck in the writer, then overflow the queue
+		logger.Error("message 0")
+		<-w.entered
+		for i := 1; i < 10; i++ {
+			logger.Errorf("message %d", i)
+		}
+
+		if logger.Dropped() != 7 {
+			t.Errorf("Policy %d: expected 7 dropped messages, got %d", test.policy, logger.Dropped())
+		}
+
+		close(w.gate)
+		logger.Close()
+
+		lines := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+		var messages []string
+		for _, line := range lines {
+			if strings.HasPrefix(line, "[ERR] ") {
+				messages = append(messages, strings.TrimPrefix(line, "[ERR] "))
+			}
+		}
+		if fmt.Sprint(messages) != fmt.Sprint(test.expected) {
+			t.Errorf("Policy %d: expected messages %v, got %v", test.policy, test.expected, messages)
+		}
+		if !strings.Contains(w.String(), "[WRN] dropped 7 log messages") {
+			t.Errorf("Policy %d: expected a dropped message report, got %q", test.policy, w.String())
+		}
+	}
+}
+
+func TestAsyncFlushReportsDropped(t *testing.T) {
+	w := newGateWriter()
+	logger := DefaultLogger().EnableAsync(1, OverflowDropNewest)
+	defer logger.Close()
+	logger.Output = w
+
+	logger.Error("message 0")
+	<-w.entered
+	logger.Error("message 1")
+	logger.Error("message 2")
+
+	close(w.gate)
+	logger.Flush()
+	if !strings.Contains(w.String(), "[WRN] dropped 1 log messages") {
+		t.Errorf("Expected Flush to report dropped messages, got %q", w.String())
+	}
+}
+
+func TestAsyncConcurrentFlush(t *testing.T) {
+	w := &serialWriter{}
+	logger := DefaultLogger().EnableAsync(8, OverflowBlock)
+	logger.Output = w
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Error("concurrent", j)
+				if j%25 == 0 {
+					logger.Flush()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	logger.Close()
+
+	if n := w.overlaps.Load(); n != 0 {
+		t.Errorf("Expected writes to never overlap, got %d", n)
+	}
+	if n := strings.Count(w.buf.String(), "\n"); n != 800 {
+		t.Errorf("Expected 800 lines, got %d", n)
+	}
+}
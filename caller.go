@@ -0,0 +1,96 @@
+package logerr
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth is the maximum number of frames recorded by callers
+const maxStackDepth = 32
+
+// callers records the program counters of the calling goroutine's stack
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	return pcs[:n]
+}
+
+// resolveFrames converts program counters into frames,
+// dropping the leading frames that belong to this package
+func resolveFrames(pcs []uintptr) []runtime.Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var result []runtime.Frame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if len(result) > 0 || !isInternalFrame(frame) {
+			result = append(result, frame)
+		}
+		if !more {
+			return result
+		}
+	}
+}
+
+// pkgPrefix is the prefix of fully qualified function names in this package
+var pkgPrefix = reflect.TypeOf(Logger{}).PkgPath() + "."
+
+// isInternalFrame reports whether frame belongs to this package's non-test code
+func isInternalFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, pkgPrefix) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// callerPC returns the program counter of the first caller outside this package
+func callerPC() uintptr {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+	for _, pc := range pcs[:n] {
+		if _, ok := externalFrame(pc); ok {
+			return pc
+		}
+	}
+	return 0
+}
+
+// externalFrame resolves pc, skipping frames of this package inlined at that location
+func externalFrame(pc uintptr) (runtime.Frame, bool) {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame) {
+			return frame, frame.PC != 0
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// formatCaller renders a frame as file:line, optionally with the full path
+// and the function name, according to the logger's caller settings
+func formatCaller(l *Logger, frame runtime.Frame) string {
+	file := frame.File
+	if !l.CallerFullPath {
+		file = filepath.Base(file)
+	}
+
+	caller := fmt.Sprintf("%s:%d", file, frame.Line)
+	if l.CallerFunction {
+		caller += " " + shortFunction(frame.Function)
+	}
+	return caller
+}
+
+// shortFunction strips the import path from a fully qualified function name
+func shortFunction(function string) string {
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		return function[i+1:]
+	}
+	return function
+}
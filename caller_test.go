@@ -0,0 +1,110 @@
+package logerr
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// line returns the line number of its caller
+func line() int {
+	_, _, n, _ := runtime.Caller(1)
+	return n
+}
+
+func TestShowCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().EnableCaller()
+	logger.Output = &buf
+	logger.Level = LogLevelDebug
+
+	check := func(name string, want int) {
+		t.Helper()
+		expected := fmt.Sprintf("caller_test.go:%d [", want)
+		if !strings.HasPrefix(buf.String(), expected) {
+			t.Errorf("%s: expected output to start with %q, got %q", name, expected, buf.String())
+		}
+		buf.Reset()
+	}
+
+	// Test Logger methods
+	logger.Info("method")
+	check("Info", line()-1)
+	logger.Errorf("method %s", "formatted")
+	check("Errorf", line()-1)
+
+	// Test derived loggers
+	sub := logger.Add("sub")
+	sub.Warn("derived")
+	check("Add().Warn", line()-1)
+
+	// Test Wrap with LogWrappedErrors
+	logger.LogWrappedErrors = true
+	logger.Wrap("wrapped")
+	check("Wrap", line()-1)
+	logger.LogWrappedErrors = false
+
+	// Test global helpers
+	originalG := Global()
+	defer func() { SetGlobal(originalG) }()
+	SetGlobal(logger)
+	Debug("global")
+	check("global Debug", line()-1)
+	Infof("global %s", "formatted")
+	check("global Infof", line()-1)
+	logger.LogWrappedErrors = true
+	Wrap("global wrapped")
+	check("global Wrap", line()-1)
+	logger.LogWrappedErrors = false
+
+	// Test the slog handler
+	slog.New(NewSlogHandler(logger)).Error("slog")
+	check("slog", line()-1)
+
+	// Test full paths and function names
+	logger.CallerFullPath = true
+	logger.CallerFunction = true
+	logger.Error("full")
+	n := line() - 1
+	_, file, _, _ := runtime.Caller(0)
+	expected := fmt.Sprintf("%s:%d logerr.TestShowCaller [ERR] full\n", file, n)
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+	buf.Reset()
+
+	// Test that JSON output has a caller key
+	logger.CallerFullPath = false
+	logger.CallerFunction = false
+	logger.SetFormatter(JSONFormatter{})
+	logger.Error("json")
+	expected = fmt.Sprintf(`"caller":"caller_test.go:%d"`, line()-1)
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected JSON output to contain %q, got %q", expected, buf.String())
+	}
+	buf.Reset()
+
+	// Test that the caller is omitted when disabled
+	logger.SetFormatter(TextFormatter{}).DisableCaller()
+	logger.Error("no caller")
+	if buf.String() != "[ERR] no caller\n" {
+		t.Errorf("Expected no caller when disabled, got %q", buf.String())
+	}
+}
+
+func TestShortFunction(t *testing.T) {
+	tests := map[string]string{
+		"github.com/audibleblink/logerr.Wrap":       "logerr.Wrap",
+		"main.main":                                 "main.main",
+		"example.com/a/b.(*T).Method.func1":         "b.(*T).Method.func1",
+		"github.com/audibleblink/logerr.TestCaller": "logerr.TestCaller",
+	}
+	for input, expected := range tests {
+		if got := shortFunction(input); got != expected {
+			t.Errorf("shortFunction(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package logerr
+
+import "time"
+
+// timeSource supplies the current time to types whose tests control it
+type timeSource struct {
+	// now returns the current time; overridden in tests
+	now func() time.Time
+}
+
+// clock returns the current time
+func (ts timeSource) clock() time.Time {
+	if ts.now != nil {
+		return ts.now()
+	}
+	return time.Now()
+}
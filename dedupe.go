@@ -0,0 +1,128 @@
+package logerr
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// deduper collapses consecutive identical messages into a repeat count
+type deduper struct {
+	mu sync.Mutex
+
+	// timeout is how long repeats are held before their summary is written
+	// Deduplication is disabled when it is not positive
+	timeout time.Duration
+
+	// last is the most recent distinct message and the logger snapshot that logged it
+	last   *Record
+	logger *Logger
+
+	// repeats counts the copies of last held back since its summary was written
+	repeats int
+	timer   *time.Timer
+}
+
+// repeated reports whether r repeats the previous message and was held back
+// When a different message arrives, the summary of the previous one is written first
+func (d *deduper) repeated(l *Logger, r Record) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timeout <= 0 {
+		return false
+	}
+
+	if d.last != nil && sameMessage(*d.last, r) {
+		d.repeats++
+		if d.timer == nil {
+			d.timer = time.AfterFunc(d.timeout, d.expire)
+		}
+		return true
+	}
+
+	d.summarize()
+	d.last, d.logger = &r, l
+	return false
+}
+
+// sameMessage reports whether two records have the same level, context, message,
+// fields and error, so collapsing them loses nothing from the formatted output
+func sameMessage(a, b Record) bool {
+	return a.Level == b.Level && a.Message == b.Message && slices.Equal(a.Context, b.Context) &&
+		slices.EqualFunc(a.Fields, b.Fields, sameField) && sameError(a.Err, b.Err)
+}
+
+// sameField reports whether two fields have the same key and value
+// Values are compared by their printed form, since they may not be comparable
+func sameField(a, b Field) bool {
+	return a.Key == b.Key && fmt.Sprint(a.Value) == fmt.Sprint(b.Value)
+}
+
+// sameError reports whether two errors are both nil, or print the same text
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Error() == b.Error()
+}
+
+// summarize writes the repeat count of the last message, if any are held back
+// It must be called with d.mu held
+func (d *deduper) summarize() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if d.repeats == 0 {
+		return
+	}
+	n := d.repeats
+	d.repeats = 0
+	d.logger.dispatch(Record{
+		Time:    time.Now(),
+		Level:   d.last.Level,
+		Context: d.last.Context,
+		Message: fmt.Sprintf("last message repeated %d times", n),
+	})
+}
+
+// expire writes the summary once the timeout passes without a different message
+// Later repeats of the same message are counted again from zero
+func (d *deduper) expire() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.timer = nil
+	d.summarize()
+}
+
+// flush writes the summary of any held back repeats
+func (d *deduper) flush() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.summarize()
+}
+
+// EnableDedupe collapses consecutive identical messages, with the same level, context,
+// message, fields and error, into a single "last message repeated N times" line. The summary is written
+// when a different message arrives, or once timeout passes
+func (l *Logger) EnableDedupe(timeout time.Duration) *Logger {
+	d := l.dedupe
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.timeout = timeout
+	return l
+}
+
+// DisableDedupe writes any pending repeat summary and stops collapsing messages
+func (l *Logger) DisableDedupe() *Logger {
+	d := l.dedupe
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.summarize()
+	d.timeout, d.last, d.logger = 0, nil, nil
+	return l
+}
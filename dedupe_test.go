@@ -0,0 +1,107 @@
+package logerr
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a buffer that can be written and read from different goroutines
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDedupe(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API").SetOutput(&buf).SetLogLevel(LogLevelWarn).EnableDedupe(time.Hour)
+	auth := logger.Add("Auth")
+
+	for i := 0; i < 4; i++ {
+		logger.Error("connection refused")
+	}
+	// The same message with a different context or level is not a repeat
+	auth.Error("connection refused")
+	auth.Warn("connection refused")
+	logger.Error("timeout")
+	logger.Error("timeout")
+	logger.Flush()
+
+	expected := "[ERR] API | connection refused\n" +
+		"[ERR] API | last message repeated 3 times\n" +
+		"[ERR] API | Auth | connection refused\n" +
+		"[WRN] API | Auth | connection refused\n" +
+		"[ERR] API | timeout\n" +
+		"[ERR] API | last message repeated 1 times\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	// Test that disabling stops collapsing
+	buf.Reset()
+	logger.DisableDedupe()
+	logger.Error("timeout")
+	logger.Error("timeout")
+	if buf.String() != "[ERR] API | timeout\n[ERR] API | timeout\n" {
+		t.Errorf("Expected repeats after DisableDedupe, got %q", buf.String())
+	}
+}
+
+func TestDedupeFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetOutput(&buf).EnableDedupe(time.Hour)
+
+	alice := logger.With("user", "alice")
+	bob := logger.With("user", "bob")
+	alice.Error("login failed")
+	bob.Error("login failed")
+	bob.Error("login failed")
+	logger.Flush()
+
+	expected := "[ERR] login failed user=alice\n" +
+		"[ERR] login failed user=bob\n" +
+		"[ERR] last message repeated 1 times\n"
+	if buf.String() != expected {
+		t.Errorf("Expected messages with different fields to be kept:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestDedupeTimeout(t *testing.T) {
+	buf := &lockedBuffer{}
+	logger := DefaultLogger().SetOutput(buf).EnableDedupe(20 * time.Millisecond)
+
+	logger.Error("disk full")
+	logger.Error("disk full")
+	logger.Error("disk full")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(buf.String(), "repeated") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	expected := "[ERR] disk full\n[ERR] last message repeated 2 times\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected summary after the timeout %q, got %q", expected, buf.String())
+	}
+
+	// Repeats after the summary are counted again
+	logger.Error("disk full")
+	logger.Error("done")
+	expected += "[ERR] last message repeated 1 times\n[ERR] done\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,104 @@
+package logerr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultEnvPrefix is used by NewFromEnv when no prefix is given
+const defaultEnvPrefix = "LOGERR"
+
+// NewFromEnv creates a logger with DefaultLogger's settings, overridden by environment
+// variables named after prefix, or LOGERR when prefix is empty:
+//
+//	LOGERR_LEVEL              minimum level, by name or label, such as debug or WRN
+//	LOGERR_EXCLUSIVE          only log messages at exactly LOGERR_LEVEL
+//	LOGERR_NO_COLOR           disable colored labels; set it to false to enable them
+//	LOGERR_TIMESTAMPS         add timestamps to log messages
+//	LOGERR_CONTEXT_SEPARATOR  string used to join context elements
+//	LOGERR_FORMAT             text, json or logfmt
+//	LOGERR_OUTPUT             stderr, stdout, or the path of a file to append to
+//
+// Boolean variables accept the values understood by strconv.ParseBool. The standard
+// NO_COLOR variable disables colors whenever it is set to a non-empty value.
+// Invalid values are reported together in the returned error, and leave the
+// corresponding setting at its default
+func NewFromEnv(prefix string) (*Logger, error) {
+	if prefix == "" {
+		prefix = defaultEnvPrefix
+	}
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+
+	logger := DefaultLogger()
+	var errs []error
+	lookup := func(name string) (string, bool) {
+		value := strings.TrimSpace(os.Getenv(prefix + name))
+		return value, value != ""
+	}
+	invalid := func(name string, err error) {
+		errs = append(errs, fmt.Errorf("invalid %s%s: %w", prefix, name, err))
+	}
+	boolean := func(name string, set func(bool)) {
+		if value, ok := lookup(name); ok {
+			if b, err := strconv.ParseBool(value); err != nil {
+				invalid(name, err)
+			} else {
+				set(b)
+			}
+		}
+	}
+
+	if value, ok := lookup("LEVEL"); ok {
+		if level, err := ParseLevel(value); err != nil {
+			invalid("LEVEL", err)
+		} else {
+			logger.Level = level
+		}
+	}
+	boolean("EXCLUSIVE", func(b bool) { logger.Exclusive = b })
+	boolean("NO_COLOR", func(b bool) { logger.NoColor = b })
+	boolean("TIMESTAMPS", func(b bool) { logger.ShowTimestamps = b })
+
+	// The separator may deliberately be blank, so it is not trimmed
+	if value, ok := os.LookupEnv(prefix + "CONTEXT_SEPARATOR"); ok {
+		logger.ContextSeparator = value
+	}
+
+	if value, ok := lookup("FORMAT"); ok {
+		switch strings.ToLower(value) {
+		case "text":
+			logger.Formatter = TextFormatter{}
+		case "json":
+			logger.Formatter = JSONFormatter{}
+		case "logfmt":
+			logger.Formatter = LogfmtFormatter{}
+		default:
+			invalid("FORMAT", fmt.Errorf("unknown format %q", value))
+		}
+	}
+
+	if value, ok := lookup("OUTPUT"); ok {
+		switch strings.ToLower(value) {
+		case "stderr":
+			logger.Output = os.Stderr
+		case "stdout":
+			logger.Output = os.Stdout
+		default:
+			if f, err := NewReopenFile(value); err != nil {
+				invalid("OUTPUT", err)
+			} else {
+				logger.Output = f
+			}
+		}
+	}
+
+	// https://no-color.org
+	if os.Getenv("NO_COLOR") != "" {
+		logger.NoColor = true
+	}
+
+	return logger, errors.Join(errs...)
+}
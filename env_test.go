@@ -0,0 +1,86 @@
+package logerr
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("APP_LEVEL", "debug")
+	t.Setenv("APP_EXCLUSIVE", "true")
+	t.Setenv("APP_NO_COLOR", "0")
+	t.Setenv("APP_TIMESTAMPS", "1")
+	t.Setenv("APP_CONTEXT_SEPARATOR", " > ")
+	t.Setenv("APP_FORMAT", "JSON")
+	t.Setenv("APP_OUTPUT", path)
+
+	logger, err := NewFromEnv("APP_")
+	if err != nil {
+		t.Fatalf("NewFromEnv failed: %v", err)
+	}
+	if logger.Level != LogLevelDebug || !logger.Exclusive || logger.NoColor || !logger.ShowTimestamps {
+		t.Errorf("Expected level and flags from the environment, got %+v", logger)
+	}
+	if logger.ContextSeparator != " > " {
+		t.Errorf("Expected separator from the environment, got %q", logger.ContextSeparator)
+	}
+	if _, ok := logger.Formatter.(JSONFormatter); !ok {
+		t.Errorf("Expected JSONFormatter, got %T", logger.Formatter)
+	}
+
+	f, ok := logger.Output.(*ReopenFile)
+	if !ok {
+		t.Fatalf("Expected a file output, got %T", logger.Output)
+	}
+	logger.Debug("to file")
+	f.Close()
+	if content := readFile(t, path); !strings.Contains(content, `"msg":"to file"`) {
+		t.Errorf("Expected the message in the file, got %q", content)
+	}
+
+	// Test that the standard NO_COLOR variable wins
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("APP_OUTPUT", "stdout")
+	logger, err = NewFromEnv("APP")
+	if err != nil {
+		t.Fatalf("NewFromEnv failed: %v", err)
+	}
+	if !logger.NoColor {
+		t.Errorf("Expected NO_COLOR to disable colors")
+	}
+}
+
+func TestNewFromEnvDefaults(t *testing.T) {
+	t.Setenv("LOGERR_LEVEL", "")
+	logger, err := NewFromEnv("")
+	if err != nil {
+		t.Fatalf("NewFromEnv failed: %v", err)
+	}
+	if logger.Level != LogLevelError || logger.ContextSeparator != " | " {
+		t.Errorf("Expected DefaultLogger settings, got %+v", logger)
+	}
+}
+
+func TestNewFromEnvErrors(t *testing.T) {
+	t.Setenv("LOGERR_LEVEL", "loud")
+	t.Setenv("LOGERR_TIMESTAMPS", "sometimes")
+	t.Setenv("LOGERR_FORMAT", "xml")
+	t.Setenv("LOGERR_EXCLUSIVE", "yes please")
+	t.Setenv("LOGERR_OUTPUT", filepath.Join(t.TempDir(), "missing", "app.log"))
+
+	logger, err := NewFromEnv("LOGERR")
+	if err == nil {
+		t.Fatalf("Expected an error for invalid values")
+	}
+	for _, name := range []string{"LOGERR_LEVEL", "LOGERR_TIMESTAMPS", "LOGERR_FORMAT", "LOGERR_EXCLUSIVE", "LOGERR_OUTPUT"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected the error to mention %s, got %v", name, err)
+		}
+	}
+	if logger == nil || logger.Level != LogLevelError {
+		t.Errorf("Expected a logger with default settings alongside the error")
+	}
+}
@@ -0,0 +1,141 @@
+package logerr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ContextError is the error returned by Wrap
+// It records the context segments the error passed through,
+// so they can be recovered with errors.As
+type ContextError struct {
+	err       error
+	context   []string
+	separator string
+	wrappedAt time.Time
+	fields    []Field
+	stack     []uintptr
+}
+
+// newContextError wraps err with the logger's current context and fields
+func (l *Logger) newContextError(err error) *ContextError {
+	ce := &ContextError{
+		err:       err,
+		context:   slices.Clone(l.context),
+		separator: l.ContextSeparator,
+		wrappedAt: time.Now(),
+		fields:    l.Fields(),
+	}
+	if l.CaptureStack {
+		ce.stack = callers()
+	}
+	return ce
+}
+
+// Error returns the context joined by the separator, followed by the wrapped error text
+func (e *ContextError) Error() string {
+	return strings.Join(e.context, e.separator) + e.separator + e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *ContextError) Unwrap() error { return e.err }
+
+// Context returns a copy of the context segments at the time of wrapping
+func (e *ContextError) Context() []string { return slices.Clone(e.context) }
+
+// Separator returns the separator used to join the context in Error
+func (e *ContextError) Separator() string { return e.separator }
+
+// Time returns when the error was wrapped
+func (e *ContextError) Time() time.Time { return e.wrappedAt }
+
+// Fields returns the fields attached to the logger when the error was wrapped
+func (e *ContextError) Fields() []Field { return slices.Clone(e.fields) }
+
+// StackTrace returns the frames recorded when the error was wrapped,
+// or nil when the wrapping logger did not have CaptureStack enabled
+func (e *ContextError) StackTrace() []runtime.Frame {
+	return resolveFrames(e.stack)
+}
+
+// Format implements fmt.Formatter
+// %+v prints the error text followed by the recorded stack trace, if any
+func (e *ContextError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Error())
+		for _, frame := range e.StackTrace() {
+			fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// messageError replaces the text of an error with a log message,
+// such as the formatted message passed to Panicf, while keeping it in the chain
+type messageError struct {
+	msg string
+	err error
+}
+
+// Error returns the log message
+func (e *messageError) Error() string { return e.msg }
+
+// Unwrap returns the original error
+func (e *messageError) Unwrap() error { return e.err }
+
+// ErrorStack returns the stack recorded by the innermost wrap in err's chain
+// that captured one, or nil if none did
+func ErrorStack(err error) []runtime.Frame {
+	var stack []uintptr
+	for err != nil {
+		if ce, ok := err.(*ContextError); ok && ce.stack != nil {
+			stack = ce.stack
+		}
+		err = errors.Unwrap(err)
+	}
+	return resolveFrames(stack)
+}
+
+// ErrorFields returns the fields attached to err, or any error in its chain, by Wrap
+func ErrorFields(err error) []Field {
+	var fields []Field
+	for err != nil {
+		if fe, ok := err.(interface{ Fields() []Field }); ok {
+			fields = mergeFields(fields, fe.Fields())
+		}
+		err = errors.Unwrap(err)
+	}
+	return fields
+}
+
+// ErrorContext returns the context segments of every ContextError in err's chain,
+// outermost wrap first
+func ErrorContext(err error) [][]string {
+	var contexts [][]string
+	for err != nil {
+		if ce, ok := err.(*ContextError); ok {
+			contexts = append(contexts, ce.Context())
+		}
+		err = errors.Unwrap(err)
+	}
+	return contexts
+}
+
+// mergeFields appends the fields from extra whose keys are not already present in base
+func mergeFields(base, extra []Field) []Field {
+	for _, f := range extra {
+		if !slices.ContainsFunc(base, func(b Field) bool { return b.Key == f.Key }) {
+			base = append(base, f)
+		}
+	}
+	return base
+}
@@ -0,0 +1,134 @@
+package logerr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContextError(t *testing.T) {
+	logger := DefaultLogger().SetContext("API")
+	auth := logger.Add("Auth")
+
+	before := time.Now()
+	original := errors.New("denied")
+	err := auth.Wrap(original)
+
+	// Test that the error text is unchanged
+	if err.Error() != "API | Auth | denied" {
+		t.Errorf("Expected wrapped error text 'API | Auth | denied', got %q", err.Error())
+	}
+
+	// Test that the context can be recovered with errors.As
+	var ce *ContextError
+	if !errors.As(fmt.Errorf("handler: %w", err), &ce) {
+		t.Fatalf("Expected errors.As to find a *ContextError")
+	}
+	if fmt.Sprint(ce.Context()) != "[API Auth]" {
+		t.Errorf("Expected context [API Auth], got %v", ce.Context())
+	}
+	if ce.Separator() != " | " {
+		t.Errorf("Expected separator ' | ', got %q", ce.Separator())
+	}
+	if ce.Unwrap() != original {
+		t.Errorf("Expected Unwrap to return the original error")
+	}
+	if ce.Time().Before(before) || ce.Time().After(time.Now()) {
+		t.Errorf("Expected wrap time between test start and now, got %v", ce.Time())
+	}
+
+	// Test that the returned context is a copy
+	ce.Context()[0] = "changed"
+	if ce.Context()[0] != "API" {
+		t.Errorf("Expected Context to return a copy")
+	}
+
+	// Test that an empty context keeps the leading separator
+	empty := DefaultLogger().Wrap("plain")
+	if empty.Error() != " | plain" {
+		t.Errorf("Expected ' | plain', got %q", empty.Error())
+	}
+}
+
+func TestErrorContext(t *testing.T) {
+	db := DefaultLogger().SetContext("DB")
+	api := DefaultLogger().SetContext("API")
+
+	err := api.Wrap(fmt.Errorf("query: %w", db.Wrap("timeout")))
+	contexts := ErrorContext(err)
+	if fmt.Sprint(contexts) != "[[API] [DB]]" {
+		t.Errorf("Expected contexts [[API] [DB]], got %v", contexts)
+	}
+
+	if ErrorContext(errors.New("plain")) != nil {
+		t.Errorf("Expected no contexts for a plain error")
+	}
+}
+
+func TestStackCapture(t *testing.T) {
+	// Test that no stack is recorded by default
+	logger := DefaultLogger().SetContext("API")
+	err := logger.Wrap("no stack")
+	if ErrorStack(err) != nil {
+		t.Errorf("Expected no stack without CaptureStack")
+	}
+	if fmt.Sprintf("%+v", err) != "API | no stack" {
+		t.Errorf("Expected %%+v without a stack to print only the error, got %q", fmt.Sprintf("%+v", err))
+	}
+
+	// Test that the stack starts at the caller of Wrap
+	logger.EnableStackCapture()
+	err = logger.Wrap("with stack")
+	stack := ErrorStack(fmt.Errorf("outer: %w", err))
+	if len(stack) == 0 {
+		t.Fatalf("Expected a stack to be captured")
+	}
+	if !strings.HasSuffix(stack[0].Function, "TestStackCapture") {
+		t.Errorf("Expected first frame to be the test function, got %s", stack[0].Function)
+	}
+
+	// Test that the global Wrap also starts at its caller
+	originalG := Global()
+	defer func() { SetGlobal(originalG) }()
+	SetGlobal(logger)
+	stack = ErrorStack(Wrap("global"))
+	if len(stack) == 0 || !strings.HasSuffix(stack[0].Function, "TestStackCapture") {
+		t.Errorf("Expected global Wrap stack to start at the test function, got %v", stack)
+	}
+
+	// Test %+v and %v formatting
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, "API | with stack\n") || !strings.Contains(verbose, "errors_test.go:") {
+		t.Errorf("Expected %%+v to include the stack, got %q", verbose)
+	}
+	if fmt.Sprintf("%v", err) != "API | with stack" || fmt.Sprintf("%s", err) != "API | with stack" {
+		t.Errorf("Expected %%v and %%s to print only the error text")
+	}
+
+	// Test that the stack is printed under error messages only
+	var buf bytes.Buffer
+	logger.Output = &buf
+	logger.Level = LogLevelDebug
+	logger.Error(err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "[ERR] API | API | with stack" || len(lines) < 3 || !strings.HasSuffix(lines[1], "TestStackCapture") {
+		t.Errorf("Expected stack under the error message, got %q", buf.String())
+	}
+
+	buf.Reset()
+	logger.Warn(err)
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Expected no stack for warnings, got %q", buf.String())
+	}
+
+	// Test that LogWrappedErrors prints the stack of the new error
+	buf.Reset()
+	logger.LogWrappedErrors = true
+	logger.Wrap("auto logged")
+	if !strings.HasPrefix(buf.String(), "[ERR] API | auto logged\n\t") || !strings.Contains(buf.String(), "TestStackCapture") {
+		t.Errorf("Expected auto-logged error with stack, got %q", buf.String())
+	}
+}
@@ -0,0 +1,77 @@
+package logerr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ExitCoder is implemented by errors that choose the exit code used by Fatal and Fatalf
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// exitHandlers run, in registration order, before Fatal and Fatalf exit
+var (
+	exitMu       sync.Mutex
+	exitHandlers []func()
+)
+
+// RegisterExitHandler adds a function to run before Fatal and Fatalf exit the program,
+// such as flushing sinks or closing files. Handlers run in the order they were registered
+func RegisterExitHandler(handler func()) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitHandlers = append(exitHandlers, handler)
+}
+
+// runExitHandlers calls every registered exit handler
+// A panicking handler is reported and does not stop the others, or the exit
+func runExitHandlers() {
+	exitMu.Lock()
+	handlers := exitHandlers
+	exitMu.Unlock()
+
+	for _, handler := range handlers {
+		runExitHandler(handler)
+	}
+}
+
+// runExitHandler calls a single exit handler, recovering from a panic
+func runExitHandler(handler func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(errorOutput, "logerr: exit handler panicked: %v\n", r)
+		}
+	}()
+	handler()
+}
+
+// exitCode returns the code of the first error among args implementing ExitCoder, or 1
+func exitCode(args []any) int {
+	for _, arg := range args {
+		var coder ExitCoder
+		if err, ok := arg.(error); ok && errors.As(err, &coder) {
+			return coder.ExitCode()
+		}
+	}
+	return 1
+}
+
+// exit flushes the logger, runs the exit handlers and ends the program with code
+func (l *Logger) exit(code int) {
+	l.Flush()
+	runExitHandlers()
+	exitFunc := l.snapshot().ExitFunc
+	if exitFunc == nil {
+		exitFunc = os.Exit
+	}
+	exitFunc(code)
+}
+
+// SetExitFunc sets the function Fatal and Fatalf call to end the program
+// A nil fn restores os.Exit
+func (l *Logger) SetExitFunc(fn func(int)) *Logger {
+	return l.update(func(l *Logger) { l.ExitFunc = fn })
+}
@@ -0,0 +1,56 @@
+package logerr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// codeError is an error carrying an exit code
+type codeError struct{ code int }
+
+func (e codeError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+func (e codeError) ExitCode() int { return e.code }
+
+func TestFatalExit(t *testing.T) {
+	old := exitHandlers
+	defer func() { exitHandlers = old }()
+	exitHandlers = nil
+
+	var buf, errs bytes.Buffer
+	oldOutput := errorOutput
+	errorOutput = &errs
+	defer func() { errorOutput = oldOutput }()
+
+	var codes []int
+	var calls []string
+	logger := DefaultLogger().SetOutput(&buf).EnableAsync(8, OverflowBlock)
+	defer logger.Close()
+	logger.SetExitFunc(func(code int) {
+		// Queued messages are written before the exit handlers run
+		calls = append(calls, "exit:"+buf.String())
+		codes = append(codes, code)
+	})
+	RegisterExitHandler(func() { calls = append(calls, "first") })
+	RegisterExitHandler(func() { panic("broken handler") })
+	RegisterExitHandler(func() { calls = append(calls, "second") })
+
+	logger.Fatal("shutting down")
+	expected := []string{"first", "second", "exit:[FATAL] shutting down\n"}
+	if strings.Join(calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected calls %q, got %q", expected, calls)
+	}
+	if !strings.Contains(errs.String(), "exit handler panicked: broken handler") {
+		t.Errorf("Expected the handler panic to be reported, got %q", errs.String())
+	}
+
+	// Test that an ExitCoder anywhere in the error chain chooses the exit code
+	logger.Fatal(logger.Wrap(codeError{3}))
+	logger.Fatalf("command failed: %v", codeError{4})
+	logger.Fatal(errors.New("plain"))
+	if len(codes) != 4 || codes[0] != 1 || codes[1] != 3 || codes[2] != 4 || codes[3] != 1 {
+		t.Errorf("Expected exit codes [1 3 4 1], got %v", codes)
+	}
+}
@@ -0,0 +1,145 @@
+package logerr
+
+import (
+	"flag"
+	"strconv"
+)
+
+// verbosityLevels are the levels each -v steps through, from the least verbose
+var verbosityLevels = []LogLevel{LogLevelError, LogLevelWarn, LogLevelInfo, LogLevelDebug, LogLevelTrace}
+
+// Set implements flag.Value using ParseLevel, so a LogLevel can be registered with flag.Var
+func (level *LogLevel) Set(s string) error {
+	return level.UnmarshalText([]byte(s))
+}
+
+// loggerFlags holds the state shared by the flags registered with RegisterFlags
+type loggerFlags struct {
+	// logger is configured by the flags, or the global logger when nil
+	logger *Logger
+
+	// base is the level set by -log-level, or the logger's level at registration
+	base LogLevel
+
+	// verbosity counts the -v flags seen so far
+	verbosity int
+}
+
+// configure applies fn to the logger the flags configure
+// The global logger is replaced with a changed copy, like the package-level setters do
+func (f *loggerFlags) configure(fn func(*Logger) *Logger) {
+	if f.logger != nil {
+		fn(f.logger)
+		return
+	}
+	configureGlobal(fn)
+}
+
+// apply sets the logger's level to the base level lowered by the verbosity
+func (f *loggerFlags) apply() {
+	level := f.base
+	if f.verbosity > 0 {
+		// Start from the most verbose step that is still at least as severe as the base level
+		start := -1
+		for i, step := range verbosityLevels {
+			if levelAtLeast(step, f.base) {
+				start = i
+			}
+		}
+		level = verbosityLevels[min(start+f.verbosity, len(verbosityLevels)-1)]
+	}
+	f.configure(func(l *Logger) *Logger { return l.SetLogLevel(level) })
+}
+
+// verbosityFlag is a boolean flag that raises the verbosity by step each time it is given
+type verbosityFlag struct {
+	flags *loggerFlags
+	step  int
+}
+
+// String implements flag.Value
+func (v *verbosityFlag) String() string { return "" }
+
+// IsBoolFlag lets the flag be given without a value
+func (v *verbosityFlag) IsBoolFlag() bool { return true }
+
+// Set implements flag.Value, counting every true value
+func (v *verbosityFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil || !on {
+		return err
+	}
+	v.flags.verbosity += v.step
+	v.flags.apply()
+	return nil
+}
+
+// levelFlag sets the base level that -v flags are counted from
+type levelFlag struct {
+	flags *loggerFlags
+}
+
+// String implements flag.Value, returning the base level's name
+func (v *levelFlag) String() string {
+	if v.flags == nil {
+		return ""
+	}
+	text, _ := v.flags.base.MarshalText()
+	return string(text)
+}
+
+// Set implements flag.Value using ParseLevel
+func (v *levelFlag) Set(s string) error {
+	level, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	v.flags.base = level
+	v.flags.apply()
+	return nil
+}
+
+// exclusiveFlag toggles the logger's Exclusive setting
+type exclusiveFlag struct {
+	flags *loggerFlags
+}
+
+// String implements flag.Value
+func (v *exclusiveFlag) String() string { return "" }
+
+// IsBoolFlag lets the flag be given without a value
+func (v *exclusiveFlag) IsBoolFlag() bool { return true }
+
+// Set implements flag.Value
+func (v *exclusiveFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	v.flags.configure(func(l *Logger) *Logger { return l.SetExclusive(on) })
+	return nil
+}
+
+// RegisterFlags registers logging flags on fs, or flag.CommandLine when fs is nil, that
+// configure l, or the global logger when l is nil:
+//
+//	-log-level LEVEL  sets the level, by name or label
+//	-v, -vv, -vvv     lower the level one, two or three steps through ERR, WRN, INF, DBG and TRC
+//	                  from -log-level or the logger's level; repeatable
+//	-exclusive        shows only messages at the configured level
+func RegisterFlags(fs *flag.FlagSet, l *Logger) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	target := l
+	if target == nil {
+		target = Global()
+	}
+
+	flags := &loggerFlags{logger: l, base: target.snapshot().Level}
+	fs.Var(&levelFlag{flags}, "log-level", "minimum `level` of messages to log, such as debug or WRN")
+	fs.Var(&verbosityFlag{flags, 1}, "v", "increase log verbosity; repeat for more")
+	fs.Var(&verbosityFlag{flags, 2}, "vv", "increase log verbosity by two steps")
+	fs.Var(&verbosityFlag{flags, 3}, "vvv", "increase log verbosity by three steps")
+	fs.Var(&exclusiveFlag{flags}, "exclusive", "log only messages at exactly the configured level")
+}
@@ -0,0 +1,83 @@
+package logerr
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLevelFlagValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	level := LogLevelInfo
+	fs.Var(&level, "level", "log level")
+
+	if err := fs.Parse([]string{"-level", "WRN"}); err != nil || level != LogLevelWarn {
+		t.Errorf("Expected -level WRN to set LogLevelWarn, got %v, %v", level, err)
+	}
+	if err := fs.Parse([]string{"-level", "loud"}); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expected  LogLevel
+		exclusive bool
+	}{
+		{nil, LogLevelError, false},
+		{[]string{"-v"}, LogLevelWarn, false},
+		{[]string{"-vv"}, LogLevelInfo, false},
+		{[]string{"-vvv"}, LogLevelDebug, false},
+		{[]string{"-v", "-v", "-v", "-v"}, LogLevelTrace, false},
+		{[]string{"-vvv", "-vv"}, LogLevelTrace, false},
+		{[]string{"-v=false"}, LogLevelError, false},
+		{[]string{"-log-level", "info", "-v"}, LogLevelDebug, false},
+		{[]string{"-v", "-log-level", "notice"}, LogLevelInfo, false},
+		{[]string{"-log-level", "fatal", "-v"}, LogLevelError, false},
+		{[]string{"-log-level", "wrn", "-exclusive"}, LogLevelWarn, true},
+	}
+
+	for _, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		logger := DefaultLogger()
+		RegisterFlags(fs, logger)
+		if err := fs.Parse(test.args); err != nil {
+			t.Fatalf("Parse(%v) failed: %v", test.args, err)
+		}
+		if logger.Level != test.expected || logger.Exclusive != test.exclusive {
+			t.Errorf("Parse(%v) set Level=%v, Exclusive=%v, expected %v, %v",
+				test.args, logger.Level, logger.Exclusive, test.expected, test.exclusive)
+		}
+	}
+
+	// Test that an invalid level is reported and the defaults are listed
+	var usage strings.Builder
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&usage)
+	RegisterFlags(fs, DefaultLogger())
+	if err := fs.Parse([]string{"-log-level", "loud"}); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+	if !strings.Contains(usage.String(), `(default error)`) {
+		t.Errorf("Expected the default level in the usage, got %q", usage.String())
+	}
+}
+
+func TestRegisterFlagsGlobal(t *testing.T) {
+	old := Global()
+	defer SetGlobal(old)
+	SetGlobal(DefaultLogger())
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs, nil)
+	if err := fs.Parse([]string{"-vv", "-exclusive"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if Global().Level != LogLevelInfo || !Global().Exclusive {
+		t.Errorf("Expected the global logger to be configured, got Level=%v, Exclusive=%v",
+			Global().Level, Global().Exclusive)
+	}
+}
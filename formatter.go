@@ -0,0 +1,280 @@
+package logerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Record is a single log event handed to a Formatter
+type Record struct {
+	// Time the message was logged
+	Time time.Time
+
+	// Level the message was logged at
+	Level LogLevel
+
+	// Context segments of the logger, outermost first
+	Context []string
+
+	// Message is the joined text of the logged arguments
+	Message string
+
+	// Fields attached to the logger and to any logged errors
+	Fields []Field
+
+	// Err is the first error among the logged arguments, if any
+	Err error
+
+	// Stack is the call stack recorded on Err by Wrap, for messages
+	// at LogLevelError and above whose logger had CaptureStack enabled
+	Stack []runtime.Frame
+
+	// PC is the program counter of the logging call site
+	// It is only recorded when the logger has ShowCaller enabled
+	PC uintptr
+}
+
+// Caller returns the frame of the logging call site, if PC was recorded
+func (r Record) Caller() (runtime.Frame, bool) {
+	if r.PC == 0 {
+		return runtime.Frame{}, false
+	}
+	return externalFrame(r.PC)
+}
+
+// Formatter turns a Record into the bytes written to a logger's Output
+// The returned bytes should include the trailing newline
+type Formatter interface {
+	Format(l *Logger, r Record) []byte
+}
+
+// TextFormatter renders the default human-readable layout:
+// an optional timestamp, the level label, the context joined by
+// the logger's ContextSeparator, then the message and any fields
+type TextFormatter struct{}
+
+// Format implements Formatter
+func (TextFormatter) Format(l *Logger, r Record) []byte {
+	return []byte(formatText(l, r) + "\n")
+}
+
+// formatText renders a record in the TextFormatter layout, without a trailing newline
+func formatText(l *Logger, r Record) string {
+	msg := r.Message
+	for _, f := range r.Fields {
+		msg = fmt.Sprintf("%s %s=%v", msg, f.Key, f.Value)
+	}
+
+	prefix := formatLabel(r.Level, l.NoColor)
+	ctx := strings.Join(r.Context, l.ContextSeparator)
+
+	var timestamp string
+	if l.ShowTimestamps {
+		timestamp = r.Time.Format("2006-01-02 15:04:05.000 ")
+	}
+	if frame, ok := r.Caller(); ok && l.ShowCaller {
+		timestamp += formatCaller(l, frame) + " "
+	}
+
+	for _, frame := range r.Stack {
+		msg += fmt.Sprintf("\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+
+	if ctx == "" {
+		return fmt.Sprintf("%s%s%s", timestamp, prefix, msg)
+	}
+
+	return fmt.Sprintf("%s%s%s%s%s", timestamp, prefix, ctx, l.ContextSeparator, msg)
+}
+
+// stackStrings renders frames as "function file:line" strings
+func stackStrings(frames []runtime.Frame) []string {
+	lines := make([]string, len(frames))
+	for i, frame := range frames {
+		lines[i] = fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return lines
+}
+
+// JSONFormatter renders each record as a single-line JSON object with
+// time, level, caller, context, msg, error and stack keys followed by the record's fields.
+// Fields whose keys collide with those names are prefixed with "fields."
+type JSONFormatter struct {
+	// TimeFormat is the layout used for the time key
+	// Defaults to time.RFC3339Nano
+	TimeFormat string
+}
+
+// Format implements Formatter
+func (f JSONFormatter) Format(l *Logger, r Record) []byte {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = time.RFC3339Nano
+	}
+
+	context := r.Context
+	if context == nil {
+		context = []string{}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONKey(&buf, "time", r.Time.Format(timeFormat))
+	writeJSONKey(&buf, "level", labelFor(r.Level))
+	if frame, ok := r.Caller(); ok && l.ShowCaller {
+		writeJSONKey(&buf, "caller", formatCaller(l, frame))
+	}
+	writeJSONKey(&buf, "context", context)
+	writeJSONKey(&buf, "msg", r.Message)
+	if r.Err != nil {
+		writeJSONKey(&buf, "error", errorValue(r.Err))
+	}
+	if len(r.Stack) > 0 {
+		writeJSONKey(&buf, "stack", stackStrings(r.Stack))
+	}
+	for _, field := range r.Fields {
+		key := field.Key
+		if slices.Contains(jsonReservedKeys, key) {
+			key = "fields." + key
+		}
+		value := field.Value
+		if err, ok := value.(error); ok {
+			value = errorValue(err)
+		}
+		writeJSONKey(&buf, key, value)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// jsonReservedKeys are the keys JSONFormatter writes for every record
+var jsonReservedKeys = []string{"time", "level", "caller", "context", "msg", "error", "stack"}
+
+// writeJSONKey appends a "key":value member to an object being built in buf
+func writeJSONKey(buf *bytes.Buffer, key string, value any) {
+	if buf.Len() > 1 {
+		buf.WriteByte(',')
+	}
+	buf.Write(jsonValue(key))
+	buf.WriteByte(':')
+	buf.Write(jsonValue(value))
+}
+
+// jsonValue encodes v as JSON, falling back to its string form when it cannot be marshaled
+func jsonValue(v any) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		buf.Reset()
+		enc.Encode(fmt.Sprintf("%+v", v))
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// errorValue converts an error into a JSON-friendly structure
+func errorValue(err error) map[string]any {
+	value := map[string]any{
+		"msg":  err.Error(),
+		"type": fmt.Sprintf("%T", err),
+	}
+	var ce *ContextError
+	if errors.As(err, &ce) {
+		value["context"] = ce.Context()
+		value["cause"] = ce.Unwrap().Error()
+	}
+	return value
+}
+
+// LogfmtFormatter renders each record as a line of logfmt key=value pairs:
+// ts, level, caller, ctx (context joined with "|"), msg, err and stack, followed by the record's fields
+type LogfmtFormatter struct {
+	// TimeFormat is the layout used for the ts key
+	// Defaults to time.RFC3339Nano
+	TimeFormat string
+}
+
+// Format implements Formatter
+func (f LogfmtFormatter) Format(l *Logger, r Record) []byte {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = time.RFC3339Nano
+	}
+
+	var buf bytes.Buffer
+	writeLogfmtPair(&buf, "ts", r.Time.Format(timeFormat))
+	writeLogfmtPair(&buf, "level", labelFor(r.Level))
+	if frame, ok := r.Caller(); ok && l.ShowCaller {
+		writeLogfmtPair(&buf, "caller", formatCaller(l, frame))
+	}
+	if len(r.Context) > 0 {
+		writeLogfmtPair(&buf, "ctx", strings.Join(r.Context, "|"))
+	}
+	writeLogfmtPair(&buf, "msg", r.Message)
+	if r.Err != nil {
+		writeLogfmtPair(&buf, "err", r.Err.Error())
+	}
+	if len(r.Stack) > 0 {
+		writeLogfmtPair(&buf, "stack", strings.Join(stackStrings(r.Stack), "\n"))
+	}
+	for _, field := range r.Fields {
+		writeLogfmtPair(&buf, field.Key, field.Value)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// writeLogfmtPair appends a key=value pair to a logfmt line being built in buf
+func writeLogfmtPair(buf *bytes.Buffer, key string, value any) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(logfmtKey(key))
+	buf.WriteByte('=')
+	buf.WriteString(logfmtValue(value))
+}
+
+// logfmtKey removes characters that are not allowed in a logfmt key
+func logfmtKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, key)
+	if key == "" {
+		return "_"
+	}
+	return key
+}
+
+// logfmtValue renders a value, quoting and escaping it when required by logfmt
+func logfmtValue(value any) string {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if s != "" && !strings.ContainsFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError
+	}) {
+		return s
+	}
+	return string(jsonValue(s))
+}
@@ -0,0 +1,144 @@
+package logerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// upperFormatter is a custom Formatter used to test the Formatter extension point
+type upperFormatter struct{}
+
+func (upperFormatter) Format(l *Logger, r Record) []byte {
+	return []byte(fmt.Sprintf("%d %s %s\n", r.Level, strings.Join(r.Context, "/"), strings.ToUpper(r.Message)))
+}
+
+func TestTextFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API")
+	logger.Output = &buf
+	logger.Level = LogLevelDebug
+
+	// Test that the default formatter output is unchanged
+	sub := logger.Add("Auth")
+	sub.Warn("token expired")
+	expected := "[WRN] API | Auth | token expired\n"
+	if buf.String() != expected {
+		t.Errorf("Expected TextFormatter output %q, got %q", expected, buf.String())
+	}
+
+	// Test that a nil Formatter falls back to TextFormatter
+	buf.Reset()
+	logger.Formatter = nil
+	logger.Info("fallback")
+	expected = "[INF] API | fallback\n"
+	if buf.String() != expected {
+		t.Errorf("Expected nil Formatter to fall back to text output %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCustomFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API").SetFormatter(upperFormatter{})
+	logger.Output = &buf
+
+	sub := logger.Add("Auth")
+	sub.Error("denied")
+	expected := "3 API/Auth DENIED\n"
+	if buf.String() != expected {
+		t.Errorf("Expected custom formatter output %q, got %q", expected, buf.String())
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API").SetFormatter(JSONFormatter{})
+	logger.Output = &buf
+
+	sub := logger.Add("Auth")
+	sub = sub.WithFields(Field{Key: "user", Value: "al\"ice"}, Field{Key: "level", Value: 7})
+	sub.Error("login failed:", errors.New("bad <password>"))
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", buf.String(), err)
+	}
+	if !strings.HasSuffix(buf.String(), "}\n") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Expected a single JSON line, got %q", buf.String())
+	}
+	if got["level"] != "ERR" {
+		t.Errorf("Expected level ERR, got %v", got["level"])
+	}
+	if fmt.Sprint(got["context"]) != "[API Auth]" {
+		t.Errorf("Expected context [API Auth], got %v", got["context"])
+	}
+	if got["msg"] != "login failed: bad <password>" {
+		t.Errorf("Expected unescaped msg, got %v", got["msg"])
+	}
+	if got["user"] != "al\"ice" {
+		t.Errorf("Expected user field, got %v", got["user"])
+	}
+	if got["fields.level"] != float64(7) {
+		t.Errorf("Expected colliding field key to be prefixed, got %v", got)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, got["time"].(string)); err != nil {
+		t.Errorf("Expected RFC3339 time, got %v", got["time"])
+	}
+	errValue, ok := got["error"].(map[string]any)
+	if !ok || errValue["msg"] != "bad <password>" || errValue["type"] != "*errors.errorString" {
+		t.Errorf("Expected structured error value, got %v", got["error"])
+	}
+
+	// Test that an empty context is an empty array and unmarshalable values do not break output
+	buf.Reset()
+	logger.ClearContext()
+	odd := logger.With("fn", func() {})
+	odd.Error("odd value")
+	got = map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", buf.String(), err)
+	}
+	if !strings.Contains(buf.String(), `"context":[]`) {
+		t.Errorf("Expected empty context array, got %q", buf.String())
+	}
+	if _, ok := got["error"]; ok {
+		t.Errorf("Expected no error key when no error was logged, got %q", buf.String())
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API").SetFormatter(LogfmtFormatter{TimeFormat: "15:04"})
+	logger.Output = &buf
+
+	sub := logger.Add("Auth")
+	sub = sub.WithFields(
+		Field{Key: "user", Value: "alice"},
+		Field{Key: "query", Value: `a="b c"`},
+		Field{Key: "empty", Value: ""},
+		Field{Key: "bad key", Value: nil},
+	)
+	sub.Error("login failed", errors.New("bad\npassword"))
+
+	line := buf.String()
+	ts := line[len("ts="):strings.Index(line, " ")]
+	if _, err := time.Parse("15:04", ts); err != nil {
+		t.Errorf("Expected ts to use the configured format, got %q", line)
+	}
+	expected := ` level=ERR ctx=API|Auth msg="login failed bad\npassword" err="bad\npassword" user=alice query="a=\"b c\"" empty="" badkey=null` + "\n"
+	if !strings.HasSuffix(line, expected) {
+		t.Errorf("Expected logfmt line ending in %q, got %q", expected, line)
+	}
+
+	// Test that ctx is omitted when there is no context
+	buf.Reset()
+	logger.ClearContext()
+	logger.Error("plain")
+	if strings.Contains(buf.String(), "ctx=") || !strings.HasSuffix(buf.String(), " level=ERR msg=plain\n") {
+		t.Errorf("Expected no ctx key without context, got %q", buf.String())
+	}
+}
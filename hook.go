@@ -0,0 +1,61 @@
+package logerr
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+)
+
+// errorOutput receives errors returned by hooks and panics from exit handlers
+// They are written directly, never through a Logger, so a failing hook cannot recurse
+var errorOutput io.Writer = os.Stderr
+
+// hook is a function run for messages at any of its levels
+type hook struct {
+	levels []LogLevel
+	fn     func(Record) error
+}
+
+// fires reports whether the hook runs for messages at level
+// A hook without levels runs for every message
+func (h hook) fires(level LogLevel) bool {
+	return len(h.levels) == 0 || slices.Contains(h.levels, level)
+}
+
+// hookSet is the list of hooks run by a logger
+type hookSet struct {
+	mu    sync.RWMutex
+	hooks []hook
+}
+
+// list returns the current hooks
+func (set *hookSet) list() []hook {
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	return set.hooks
+}
+
+// AddHook runs fn for every logged message at one of levels, or at any level if levels is empty
+// Hooks run synchronously, before the message is written
+// Errors returned by fn are reported on stderr
+func (l *Logger) AddHook(levels []LogLevel, fn func(Record) error) *Logger {
+	set := l.hooks
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.hooks = append(slices.Clip(set.hooks), hook{levels: slices.Clone(levels), fn: fn})
+	return l
+}
+
+// runHooks calls every hook registered for the record's level
+func (l *Logger) runHooks(r Record) {
+	for _, h := range l.hooks.list() {
+		if !h.fires(r.Level) {
+			continue
+		}
+		if err := h.fn(r); err != nil {
+			fmt.Fprintf(errorOutput, "logerr: %s hook failed: %v\n", labelFor(r.Level), err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package logerr
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API")
+	logger.Output = &buf
+	logger.Level = LogLevelDebug
+	logger.LogWrappedErrors = true
+
+	var errs, all []Record
+	logger.AddHook([]LogLevel{LogLevelError, LogLevelFatal}, func(r Record) error {
+		errs = append(errs, r)
+		return nil
+	})
+	logger.AddHook(nil, func(r Record) error {
+		all = append(all, r)
+		return nil
+	})
+
+	// Derived loggers share hooks
+	dbLogger := logger.Add("DB")
+	db := dbLogger.With("table", "users")
+	db.Info("connected")
+	db.Errorf("query failed: %d", 42)
+	_ = logger.Wrap("wrapped failure")
+
+	if len(all) != 3 {
+		t.Fatalf("Expected catch-all hook to run 3 times, got %d", len(all))
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected error hook to run 2 times, got %d", len(errs))
+	}
+
+	r := errs[0]
+	if r.Level != LogLevelError || r.Message != "query failed: 42" {
+		t.Errorf("Expected error record for the query, got %v %q", r.Level, r.Message)
+	}
+	if !slices.Equal(r.Context, []string{"API", "DB"}) {
+		t.Errorf("Expected context [API DB], got %v", r.Context)
+	}
+	if len(r.Fields) != 1 || r.Fields[0] != (Field{"table", "users"}) {
+		t.Errorf("Expected table field, got %v", r.Fields)
+	}
+
+	var ctxErr *ContextError
+	if errs[1].Message != "wrapped failure" || !errors.As(errs[1].Err, &ctxErr) {
+		t.Errorf("Expected Wrap to run the hook with the wrapped error, got %q %v", errs[1].Message, errs[1].Err)
+	}
+
+	// Test that filtered messages do not run hooks
+	logger.SetLogLevel(LogLevelWarn)
+	logger.Info("filtered")
+	if len(all) != 3 {
+		t.Errorf("Expected filtered message to skip hooks, got %d runs", len(all))
+	}
+}
+
+func TestHookErrors(t *testing.T) {
+	var buf, hookErrs bytes.Buffer
+	old := errorOutput
+	errorOutput = &hookErrs
+	defer func() { errorOutput = old }()
+
+	logger := DefaultLogger()
+	logger.Output = &buf
+	logger.AddHook([]LogLevel{LogLevelError}, func(r Record) error {
+		return errors.New("pager unavailable")
+	})
+
+	logger.Error("disk full")
+
+	if buf.String() != "[ERR] disk full\n" {
+		t.Errorf("Expected message to be written despite the hook error, got %q", buf.String())
+	}
+	if !strings.Contains(hookErrs.String(), "ERR hook failed: pager unavailable") {
+		t.Errorf("Expected hook error to be reported, got %q", hookErrs.String())
+	}
+	if strings.Contains(buf.String(), "pager unavailable") {
+		t.Errorf("Expected hook error to bypass the logger, got %q", buf.String())
+	}
+}
@@ -0,0 +1,168 @@
+package logerr
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// levelsMu guards labels, names, labelColors and severities
+var levelsMu sync.RWMutex
+
+// LevelSpec describes a registered log level
+type LevelSpec struct {
+	// Label is the text shown for the level, such as "DBG"
+	Label string
+
+	// Name is the full name of the level, such as "debug", used when parsing and marshaling
+	// Defaults to the lowercased Label
+	Name string
+
+	// Severity orders the level against the others for Level filtering
+	// Built-in levels use the slog values, so LogLevelInfo is 0 and LogLevelWarn is 4
+	Severity int
+
+	// Color is used for the label when colors are enabled
+	// A nil Color prints the label uncolored
+	Color *color.Color
+}
+
+// RegisterLevel adds a custom log level, or replaces the spec of an existing one
+// The level can then be logged with Log and Logf, and used as a logger's or sink's Level
+func RegisterLevel(level LogLevel, spec LevelSpec) error {
+	if spec.Label == "" {
+		return fmt.Errorf("logerr: level %d has an empty label", level)
+	}
+	if spec.Name == "" {
+		spec.Name = strings.ToLower(spec.Label)
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	for _, text := range []string{spec.Label, spec.Name} {
+		if other, ok := lookupText(text); ok && other != level {
+			return fmt.Errorf("logerr: %q is already used by level %d", text, other)
+		}
+	}
+
+	labels[level] = spec.Label
+	names[level] = spec.Name
+	severities[level] = spec.Severity
+	if spec.Color != nil {
+		labelColors[level] = spec.Color
+	} else {
+		delete(labelColors, level)
+	}
+	return nil
+}
+
+// LookupLevel returns the spec of a registered level
+func LookupLevel(level LogLevel) (LevelSpec, bool) {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	label, ok := labels[level]
+	if !ok {
+		return LevelSpec{}, false
+	}
+	return LevelSpec{Label: label, Name: names[level], Severity: severities[level], Color: labelColors[level]}, true
+}
+
+// Levels returns the registered levels, from least to most severe
+func Levels() []LogLevel {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	return slices.SortedFunc(maps.Keys(labels), compareLevels)
+}
+
+// compareLevels orders levels by severity, then by value
+// It must be called with levelsMu held
+func compareLevels(a, b LogLevel) int {
+	return cmp.Or(cmp.Compare(severities[a], severities[b]), cmp.Compare(a, b))
+}
+
+// severityOf returns the severity of a level
+// Unregistered levels are ranked by their value, on the same scale as the built-in levels
+// It must be called with levelsMu held
+func severityOf(level LogLevel) int {
+	if severity, ok := severities[level]; ok {
+		return severity
+	}
+	return (int(level) - 1) * 4
+}
+
+// levelAtLeast reports whether level is at least as severe as min
+func levelAtLeast(level, min LogLevel) bool {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	return severityOf(level) >= severityOf(min)
+}
+
+// levelSeverity returns the severity of a level
+func levelSeverity(level LogLevel) int {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	return severityOf(level)
+}
+
+// lookupText finds the level whose label or name matches text, ignoring case
+// It must be called with levelsMu held
+func lookupText(text string) (LogLevel, bool) {
+	for level, label := range labels {
+		if strings.EqualFold(label, text) || strings.EqualFold(names[level], text) {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// ParseLevel returns the level whose label, such as "WRN", or name, such as "warn",
+// matches s, ignoring case. Numeric values are accepted for unregistered levels
+func ParseLevel(s string) (LogLevel, error) {
+	text := strings.TrimSpace(s)
+	levelsMu.RLock()
+	level, ok := lookupText(text)
+	levelsMu.RUnlock()
+	if ok {
+		return level, nil
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		return LogLevel(n), nil
+	}
+	return 0, fmt.Errorf("logerr: unknown log level %q", s)
+}
+
+// String returns the level's label, such as "DBG"
+func (level LogLevel) String() string {
+	if label := labelFor(level); label != "" {
+		return label
+	}
+	return "LogLevel(" + strconv.Itoa(int(level)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler using the level's name, such as "debug"
+// Unregistered levels are marshaled as their numeric value
+func (level LogLevel) MarshalText() ([]byte, error) {
+	levelsMu.RLock()
+	name, ok := names[level]
+	levelsMu.RUnlock()
+	if !ok {
+		return strconv.AppendInt(nil, int64(level), 10), nil
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLevel
+func (level *LogLevel) UnmarshalText(text []byte) error {
+	parsed, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = parsed
+	return nil
+}
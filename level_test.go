@@ -0,0 +1,173 @@
+package logerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestBuiltinLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetOutput(&buf).SetLogLevel(LogLevelTrace)
+
+	logger.Trace("trace")
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Success("success")
+	logger.Notice("notice")
+	logger.Warn("warn")
+
+	expected := "[TRC] trace\n[DBG] debug\n[INF] info\n[SUC] success\n[NTC] notice\n[WRN] warn\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	expectedOrder := []LogLevel{
+		LogLevelTrace, LogLevelDebug, LogLevelInfo, LogLevelSuccess, LogLevelNotice,
+		LogLevelWarn, LogLevelError, LogLevelFatal, LogLevelPanic,
+	}
+	if got := Levels(); !slices.Equal(got, expectedOrder) {
+		t.Errorf("Expected levels %v, got %v", expectedOrder, got)
+	}
+
+	// Test that levels are filtered by severity rather than by value
+	tests := []struct {
+		level     LogLevel
+		logLevel  LogLevel
+		exclusive bool
+		expected  bool
+	}{
+		{LogLevelTrace, LogLevelDebug, false, false},
+		{LogLevelDebug, LogLevelTrace, false, true},
+		{LogLevelNotice, LogLevelInfo, false, true},
+		{LogLevelNotice, LogLevelWarn, false, false},
+		{LogLevelSuccess, LogLevelNotice, false, false},
+		{LogLevelError, LogLevelNotice, false, true},
+		{LogLevelNotice, LogLevelNotice, true, true},
+		{LogLevelWarn, LogLevelNotice, true, false},
+	}
+	for _, test := range tests {
+		logger.Level = test.logLevel
+		logger.Exclusive = test.exclusive
+		if got := logger.shouldLog(test.level); got != test.expected {
+			t.Errorf("shouldLog(%v) with Level=%v, Exclusive=%v returned %v, expected %v",
+				test.level, test.logLevel, test.exclusive, got, test.expected)
+		}
+	}
+
+	if toSlogLevel(LogLevelTrace) != slog.LevelDebug-4 || toSlogLevel(LogLevelPanic) != slog.LevelError+8 {
+		t.Errorf("Expected slog levels to follow severity")
+	}
+}
+
+func TestRegisterLevel(t *testing.T) {
+	const LogLevelAudit LogLevel = 100
+	defer func() {
+		levelsMu.Lock()
+		defer levelsMu.Unlock()
+		delete(labels, LogLevelAudit)
+		delete(names, LogLevelAudit)
+		delete(labelColors, LogLevelAudit)
+		delete(severities, LogLevelAudit)
+	}()
+
+	err := RegisterLevel(LogLevelAudit, LevelSpec{Label: "AUD", Severity: 6, Color: color.New(color.FgMagenta)})
+	if err != nil {
+		t.Fatalf("RegisterLevel failed: %v", err)
+	}
+	if spec, ok := LookupLevel(LogLevelAudit); !ok || spec.Label != "AUD" || spec.Name != "aud" || spec.Severity != 6 {
+		t.Errorf("Expected the registered spec, got %+v", spec)
+	}
+
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetOutput(&buf).SetLogLevel(LogLevelWarn).EnableColors()
+	logger.Log(LogLevelAudit, "user", "deleted")
+	logger.Logf(LogLevelNotice, "below %s", "warn")
+	if buf.String() != "\x1b[35m[AUD] \x1b[0muser deleted\n" {
+		t.Errorf("Expected custom level between WRN and ERR to be logged, got %q", buf.String())
+	}
+
+	// Test that a custom level works as an exclusive Level
+	buf.Reset()
+	logger.DisableColors().SetLogLevel(LogLevelAudit).SetExclusive(true)
+	logger.Error("error")
+	logger.Log(LogLevelAudit, "audit")
+	if buf.String() != "[AUD] audit\n" {
+		t.Errorf("Expected only the custom level, got %q", buf.String())
+	}
+
+	if err := RegisterLevel(LogLevel(101), LevelSpec{Label: "WRN"}); err == nil {
+		t.Errorf("Expected an error for a duplicate label")
+	}
+	if err := RegisterLevel(LogLevel(101), LevelSpec{Label: "NEW", Name: "Debug"}); err == nil {
+		t.Errorf("Expected an error for a duplicate name")
+	}
+	if err := RegisterLevel(LogLevel(101), LevelSpec{}); err == nil {
+		t.Errorf("Expected an error for an empty label")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected LogLevel
+	}{
+		{"DBG", LogLevelDebug},
+		{"debug", LogLevelDebug},
+		{"wrn", LogLevelWarn},
+		{"Warn", LogLevelWarn},
+		{" ERROR ", LogLevelError},
+		{"trace", LogLevelTrace},
+		{"NTC", LogLevelNotice},
+		{"fatal", LogLevelFatal},
+		{"panic", LogLevelPanic},
+		{"42", LogLevel(42)},
+	}
+	for _, test := range tests {
+		got, err := ParseLevel(test.text)
+		if err != nil || got != test.expected {
+			t.Errorf("ParseLevel(%q) = %v, %v, expected %v", test.text, got, err, test.expected)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+}
+
+func TestLevelText(t *testing.T) {
+	if LogLevelWarn.String() != "WRN" || LogLevel(42).String() != "LogLevel(42)" {
+		t.Errorf("Expected labels from String, got %s and %s", LogLevelWarn, LogLevel(42))
+	}
+
+	type config struct {
+		Level LogLevel `json:"level"`
+		Other LogLevel `json:"other"`
+	}
+	data, err := json.Marshal(config{Level: LogLevelWarn, Other: LogLevel(42)})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"level":"warn","other":"42"}` {
+		t.Errorf("Expected levels marshaled by name, got %s", data)
+	}
+
+	var decoded config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Level != LogLevelWarn || decoded.Other != LogLevel(42) {
+		t.Errorf("Expected levels to round-trip, got %+v", decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"level":"DBG"}`), &decoded); err != nil || decoded.Level != LogLevelDebug {
+		t.Errorf("Expected short labels to unmarshal, got %v, %v", decoded.Level, err)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"loud"}`), &decoded); err == nil {
+		t.Errorf("Expected an error for an unknown level")
+	}
+}
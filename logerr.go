@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
@@ -21,31 +25,132 @@ const (
 	LogLevelWarn
 	LogLevelError
 	LogLevelFatal
+
+	// LogLevelPanic ranks above LogLevelFatal, so a logger set to FATAL still shows panics
+	LogLevelPanic
+
+	// LogLevelNotice ranks between LogLevelInfo and LogLevelWarn
+	LogLevelNotice
+
+	// LogLevelSuccess ranks between LogLevelInfo and LogLevelNotice
+	LogLevelSuccess
 )
 
+// LogLevelTrace ranks below LogLevelDebug
+const LogLevelTrace LogLevel = -1
+
 // String labels for each log level
 var labels = map[LogLevel]string{
-	LogLevelDebug: "DBG",
-	LogLevelInfo:  "INF",
-	LogLevelWarn:  "WRN",
-	LogLevelError: "ERR",
-	LogLevelFatal: "FATAL",
+	LogLevelTrace:   "TRC",
+	LogLevelDebug:   "DBG",
+	LogLevelInfo:    "INF",
+	LogLevelSuccess: "SUC",
+	LogLevelNotice:  "NTC",
+	LogLevelWarn:    "WRN",
+	LogLevelError:   "ERR",
+	LogLevelFatal:   "FATAL",
+	LogLevelPanic:   "PANIC",
+}
+
+// Names for each log level, used when parsing and marshaling levels
+var names = map[LogLevel]string{
+	LogLevelTrace:   "trace",
+	LogLevelDebug:   "debug",
+	LogLevelInfo:    "info",
+	LogLevelSuccess: "success",
+	LogLevelNotice:  "notice",
+	LogLevelWarn:    "warn",
+	LogLevelError:   "error",
+	LogLevelFatal:   "fatal",
+	LogLevelPanic:   "panic",
 }
 
 // Color configurations for each log level
 var labelColors = map[LogLevel]*color.Color{
-	LogLevelDebug: color.New(color.FgCyan),
-	LogLevelInfo:  color.New(color.FgGreen),
-	LogLevelWarn:  color.New(color.FgYellow),
-	LogLevelError: color.New(color.FgRed),
-	LogLevelFatal: color.New(color.BgRed),
+	LogLevelTrace:   color.New(color.FgHiBlack),
+	LogLevelDebug:   color.New(color.FgCyan),
+	LogLevelInfo:    color.New(color.FgGreen),
+	LogLevelSuccess: color.New(color.FgHiGreen),
+	LogLevelNotice:  color.New(color.FgBlue),
+	LogLevelWarn:    color.New(color.FgYellow),
+	LogLevelError:   color.New(color.FgRed),
+	LogLevelFatal:   color.New(color.BgRed),
+	LogLevelPanic:   color.New(color.FgHiRed, color.Bold),
+}
+
+// Severities order the log levels for Level filtering
+// They match the slog levels, so LogLevelInfo is 0 and LogLevelError is 8
+var severities = map[LogLevel]int{
+	LogLevelTrace:   -8,
+	LogLevelDebug:   -4,
+	LogLevelInfo:    0,
+	LogLevelSuccess: 1,
+	LogLevelNotice:  2,
+	LogLevelWarn:    4,
+	LogLevelError:   8,
+	LogLevelFatal:   12,
+	LogLevelPanic:   16,
+}
+
+// Field is a key/value pair attached to log messages and wrapped errors
+type Field struct {
+	Key   string
+	Value any
+}
+
+// global holds the default logger used by the package-level functions
+var global atomic.Pointer[Logger]
+
+func init() {
+	global.Store(DefaultLogger())
+}
+
+// Global returns the global default logger
+// The package-level setters install a changed copy instead of changing the returned logger
+func Global() *Logger {
+	return global.Load()
+}
+
+// SetGlobal replaces the global default logger
+// Passing nil resets it to a new DefaultLogger
+func SetGlobal(l *Logger) {
+	if l == nil {
+		l = DefaultLogger()
+	}
+	global.Store(l)
+}
+
+// updateGlobal atomically replaces the global logger with the result of fn
+func updateGlobal(fn func(*Logger) *Logger) {
+	for {
+		old := global.Load()
+		if global.CompareAndSwap(old, fn(old)) {
+			return
+		}
+	}
 }
 
-// Global default logger instance
-var G = DefaultLogger()
+// configureGlobal replaces the global logger with a copy changed by fn,
+// so the logger returned by Global is never changed after it is stored
+func configureGlobal(fn func(*Logger) *Logger) {
+	updateGlobal(func(l *Logger) *Logger { return fn(l.snapshot()) })
+}
 
 // Logger provides structured logging capabilities
+// Create loggers with DefaultLogger. Their settings, such as Level and Output,
+// are fields of an embedded struct that each Logger points to, so copying a Logger,
+// as the value-receiver logging methods do, never races with a setter.
+//
+// A logger derived with Add, With, WithFields or SetContext has its own copy of the
+// settings, but shares the sinks, hooks, sampling and rate limits, duplicate collapsing
+// and asynchronous queue of the logger it was derived from
 type Logger struct {
+	*settings
+}
+
+// settings holds a Logger's configuration
+// The pointer to it is never reassigned; Add, With and SetContext give the new logger a copy
+type settings struct {
 	// Level dictates the minimum LogLevel that will be output
 	Level LogLevel
 
@@ -60,103 +165,291 @@ type Logger struct {
 	// according to level and context, before returning the error
 	LogWrappedErrors bool
 
+	// CaptureStack, when enabled, records the call stack on errors returned by Wrap
+	CaptureStack bool
+
 	// Additional prefix text to add context to log messages
 	context []string
 
+	// Key/value data attached to log messages and wrapped errors
+	fields []Field
+
 	// NoColor disables colored output when true
 	NoColor bool
 
 	// ShowTimestamps adds timestamps to log messages when true
 	ShowTimestamps bool
 
+	// ShowCaller adds the file:line of the logging call site to log messages when true
+	ShowCaller bool
+
+	// CallerFullPath prints the caller's full file path instead of its base name
+	CallerFullPath bool
+
+	// CallerFunction adds the caller's function name after its file:line
+	CallerFunction bool
+
 	// ContextSeparator is used to join context elements
 	// Defaults to " | "
 	ContextSeparator string
+
+	// Formatter renders log records into the bytes written to Output
+	// Defaults to TextFormatter
+	Formatter Formatter
+
+	// Handler, when set, receives every log message as a slog.Record
+	// instead of it being formatted and written to Output
+	Handler slog.Handler
+
+	// ExitFunc is called by Fatal and Fatalf to end the program
+	// Defaults to os.Exit
+	ExitFunc func(int)
+
+	// async, when set, queues records for a background goroutine
+	async *asyncQueue
+
+	// sinks are additional destinations for log messages
+	sinks *sinkSet
+
+	// hooks run for messages at their levels
+	hooks *hookSet
+
+	// limits apply sampling and rate limiting to messages
+	limits *limiter
+
+	// dedupe collapses repeated messages
+	dedupe *deduper
+
+	// locks guard the configuration and serialize writes to Output
+	locks *loggerLocks
+}
+
+// loggerLocks guard the settings of a logger and the loggers derived from it
+type loggerLocks struct {
+	// config guards the logger's fields
+	config sync.Mutex
+
+	// output serializes writes to Output, so a slow writer
+	// does not hold up goroutines reading the configuration
+	output sync.Mutex
 }
 
 // DefaultLogger creates a new logger with default settings
+// The state shared with derived loggers is allocated here, and never replaced
+// except for the asynchronous queue
 func DefaultLogger() *Logger {
-	logger := &Logger{
+	logger := &Logger{&settings{
 		Level:            LogLevelError,
 		Output:           os.Stderr,
 		NoColor:          true,
 		ContextSeparator: " | ",
-		context: make([]string, 0),
-	}
-	color.NoColor = logger.NoColor
+		Formatter:        TextFormatter{},
+		context:          make([]string, 0),
+		sinks:            new(sinkSet),
+		hooks:            new(hookSet),
+		limits:           new(limiter),
+		dedupe:           new(deduper),
+		locks:            new(loggerLocks),
+	}}
 	return logger
 }
 
-// SetAsGlobal sets this logger as the global default logger
+// mutex returns the configuration lock
+func (l *Logger) mutex() *sync.Mutex {
+	return &l.locks.config
+}
+
+// outputMutex returns the output lock
+func (l *Logger) outputMutex() *sync.Mutex {
+	return &l.locks.output
+}
+
+// snapshot returns a copy of the logger taken while holding its lock
+func (l *Logger) snapshot() *Logger {
+	mu := l.mutex()
+	mu.Lock()
+	defer mu.Unlock()
+	return l.clone()
+}
+
+// clone returns a copy of the logger with its own settings
+// It must be called with the logger's lock held, or on a snapshot
+func (l *Logger) clone() *Logger {
+	dup := *l.settings
+	return &Logger{&dup}
+}
+
+// update applies fn to the logger while holding its lock
+func (l *Logger) update(fn func(*Logger)) *Logger {
+	mu := l.mutex()
+	mu.Lock()
+	defer mu.Unlock()
+	fn(l)
+	return l
+}
+
+// SetAsGlobal sets a copy of this logger as the global default logger
 func (l Logger) SetAsGlobal() {
-	G = &l
+	SetGlobal(l.snapshot())
 }
 
 // Context returns the current context string
 func (l Logger) Context() string {
-	return strings.Join(l.context, l.ContextSeparator)
+	return l.contextString()
+}
+
+// contextString returns the current context joined by the separator
+func (l *Logger) contextString() string {
+	s := l.snapshot()
+	return strings.Join(s.context, s.ContextSeparator)
 }
 
 // ClearContext removes all context from the logger
 func (l *Logger) ClearContext() {
-	l.context = make([]string, 0)
+	l.update(func(l *Logger) { l.context = make([]string, 0) })
 }
 
-// SetContext sets a single context value, replacing any existing context
+// SetContext returns a copy of the logger with a single context value,
+// replacing any existing context
 func (l Logger) SetContext(s string) *Logger {
-	l.context = []string{s}
-	return &l
+	return l.setContext(s)
+}
+
+// setContext returns a copy of the logger with a single context value
+func (l *Logger) setContext(s string) *Logger {
+	dup := l.snapshot()
+	dup.context = []string{s}
+	return dup
 }
 
 // Add returns a copy of the logger with additional context
 // Useful for loggers that can be used in a specific scope
-func (l *Logger) Add(context string) Logger {
-	dup := *l
-	dup.context = append(dup.context, context)
+func (l Logger) Add(context string) Logger {
+	return l.add(context)
+}
+
+// add returns a copy of the logger with additional context
+func (l *Logger) add(context string) Logger {
+	dup := *l.snapshot()
+	dup.context = append(slices.Clip(dup.context), context)
+	return dup
+}
+
+// With returns a copy of the logger with an additional field
+func (l Logger) With(key string, value any) Logger {
+	return l.withFields(Field{Key: key, Value: value})
+}
+
+// WithFields returns a copy of the logger with additional fields
+// A field replaces an earlier one with the same key
+func (l Logger) WithFields(fields ...Field) Logger {
+	return l.withFields(fields...)
+}
+
+// withFields returns a copy of the logger with additional fields
+func (l *Logger) withFields(fields ...Field) Logger {
+	dup := *l.snapshot()
+	dup.fields = setFields(dup.fields, fields)
 	return dup
 }
 
+// setFields returns a copy of base with fields added, replacing those with the same key
+func setFields(base, fields []Field) []Field {
+	result := slices.Clone(base)
+	for _, f := range fields {
+		if i := slices.IndexFunc(result, func(b Field) bool { return b.Key == f.Key }); i >= 0 {
+			result[i] = f
+		} else {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// Fields returns a copy of the fields attached to the logger
+func (l Logger) Fields() []Field {
+	return append([]Field(nil), l.snapshot().fields...)
+}
+
 // EnableColors enables colored output
+// Colors are decided per logger and do not depend on color.NoColor
 func (l *Logger) EnableColors() *Logger {
-	l.NoColor = false
-	color.NoColor = l.NoColor
-	return l
+	return l.update(func(l *Logger) { l.NoColor = false })
 }
 
 // DisableColors disables colored output
 func (l *Logger) DisableColors() *Logger {
-	l.NoColor = true
-	color.NoColor = l.NoColor
-	return l
+	return l.update(func(l *Logger) { l.NoColor = true })
 }
 
 // SetContextSeparator sets the separator used for joining context elements
 func (l *Logger) SetContextSeparator(separator string) *Logger {
-	l.ContextSeparator = separator
-	return l
+	return l.update(func(l *Logger) { l.ContextSeparator = separator })
+}
+
+// SetFormatter sets the Formatter used to render log messages
+func (l *Logger) SetFormatter(f Formatter) *Logger {
+	return l.update(func(l *Logger) { l.Formatter = f })
+}
+
+// SetHandler routes log messages into a slog.Handler instead of Output
+func (l *Logger) SetHandler(h slog.Handler) *Logger {
+	return l.update(func(l *Logger) { l.Handler = h })
 }
 
 // EnableTimestamps enables timestamps in log messages
 func (l *Logger) EnableTimestamps() *Logger {
-	l.ShowTimestamps = true
-	return l
+	return l.update(func(l *Logger) { l.ShowTimestamps = true })
 }
 
 // DisableTimestamps disables timestamps in log messages
 func (l *Logger) DisableTimestamps() *Logger {
-	l.ShowTimestamps = false
-	return l
+	return l.update(func(l *Logger) { l.ShowTimestamps = false })
+}
+
+// EnableCaller adds the file:line of the logging call site to log messages
+func (l *Logger) EnableCaller() *Logger {
+	return l.update(func(l *Logger) { l.ShowCaller = true })
+}
+
+// DisableCaller removes the logging call site from log messages
+func (l *Logger) DisableCaller() *Logger {
+	return l.update(func(l *Logger) { l.ShowCaller = false })
+}
+
+// EnableStackCapture records the call stack on errors returned by Wrap
+func (l *Logger) EnableStackCapture() *Logger {
+	return l.update(func(l *Logger) { l.CaptureStack = true })
+}
+
+// DisableStackCapture stops recording the call stack on errors returned by Wrap
+func (l *Logger) DisableStackCapture() *Logger {
+	return l.update(func(l *Logger) { l.CaptureStack = false })
 }
 
 // SetLogLevel sets the log level logger
 func (l *Logger) SetLogLevel(lvl LogLevel) *Logger {
-	l.Level = lvl
-	return l
+	return l.update(func(l *Logger) { l.Level = lvl })
+}
+
+// SetExclusive sets whether _only_ messages at the configured LogLevel are shown
+func (l *Logger) SetExclusive(exclusive bool) *Logger {
+	return l.update(func(l *Logger) { l.Exclusive = exclusive })
+}
+
+// SetOutput sets the destination for log messages
+func (l *Logger) SetOutput(w io.Writer) *Logger {
+	return l.update(func(l *Logger) { l.Output = w })
 }
 
 // Wrap wraps an error with the current context
 // If a string is provided, it will be converted to an error
 func (l Logger) Wrap(val any) error {
+	return l.wrap(val)
+}
+
+// wrap wraps an error with the current context, logging it if LogWrappedErrors is enabled
+func (l *Logger) wrap(val any) error {
 	var err error
 	switch v := val.(type) {
 	case error:
@@ -167,33 +460,70 @@ func (l Logger) Wrap(val any) error {
 		err = fmt.Errorf("%v", v)
 	}
 
-	if l.LogWrappedErrors {
-		l.Error(err)
+	s := l.snapshot()
+	wrapped := s.newContextError(err)
+	if s.LogWrappedErrors && s.shouldLog(LogLevelError) && s.allowed(LogLevelError, messageToString(err)) {
+		// Log the original text, but keep the wrapped error for its fields and stack
+		s.emit(s.recordFromArgs(LogLevelError, messageToString(err), []any{wrapped}))
 	}
 
-	return fmt.Errorf("%s%s%w", l.Context(), l.ContextSeparator, err)
+	return wrapped
 }
 
 // shouldLog determines if a message at the given level should be logged
+// to Output, the Handler or any of the sinks
 func (l *Logger) shouldLog(level LogLevel) bool {
-	return (l.Level == level && l.Exclusive) || (l.Level <= level && !l.Exclusive)
+	return l.primaryEnabled(level) || l.anySinkEnabled(level)
+}
+
+// primaryEnabled determines if a message at the given level should be
+// logged to Output or the Handler
+func (l *Logger) primaryEnabled(level LogLevel) bool {
+	if l.Output == nil && l.Handler == nil {
+		return false
+	}
+	return levelEnabled(l.Level, l.Exclusive, level)
+}
+
+// levelEnabled applies the Level and Exclusive rules to a message level
+func levelEnabled(min LogLevel, exclusive bool, level LogLevel) bool {
+	return (min == level && exclusive) || (levelAtLeast(level, min) && !exclusive)
+}
+
+// enabled is shouldLog guarded by the logger's lock
+func (l *Logger) enabled(level LogLevel) bool {
+	mu := l.mutex()
+	mu.Lock()
+	defer mu.Unlock()
+	return l.shouldLog(level)
 }
 
 // formatLogMessage creates a formatted log message with the level and context
 func (l *Logger) formatLogMessage(level LogLevel, msg string) string {
-	prefix := formatLabel(level, l.NoColor)
-	ctx := l.Context()
+	return formatText(l, l.newRecord(level, msg, l.fields))
+}
 
-	var timestamp string
-	if l.ShowTimestamps {
-		timestamp = time.Now().Format("2006-01-02 15:04:05.000 ")
+// newRecord builds a Record for a message logged at level
+func (l *Logger) newRecord(level LogLevel, msg string, fields []Field) Record {
+	record := Record{
+		Time:    time.Now(),
+		Level:   level,
+		Context: slices.Clone(l.context),
+		Message: msg,
+		Fields:  fields,
 	}
-
-	if ctx == "" {
-		return fmt.Sprintf("%s%s%s", timestamp, prefix, msg)
+	if l.ShowCaller {
+		record.PC = callerPC()
 	}
+	return record
+}
 
-	return fmt.Sprintf("%s%s%s%s%s", timestamp, prefix, ctx, l.ContextSeparator, msg)
+// formatter returns the configured Formatter, falling back to TextFormatter
+func (l *Logger) formatter() Formatter {
+	if l.Formatter == nil {
+		return TextFormatter{}
+	}
+	return l.Formatter
 }
 
 // messageToString converts a message (string or error) to string
@@ -208,41 +538,143 @@ func messageToString(message any) string {
 	}
 }
 
+// argsToString joins log arguments into a message
+func argsToString(args []any) string {
+	// Format the message based on the number of arguments
+	if len(args) == 1 {
+		// Single argument case (backwards compatibility)
+		return messageToString(args[0])
+	}
+
+	// Multiple arguments case
+	msgParts := make([]string, len(args))
+	for i, arg := range args {
+		msgParts[i] = messageToString(arg)
+	}
+	return strings.Join(msgParts, " ")
+}
+
 // log outputs a message if it should be logged based on level
 // first argument can be a string or an error, any additional arguments are appended
 func (l *Logger) log(level LogLevel, args ...any) {
-	if l.shouldLog(level) {
+	if s := l.snapshot(); s.shouldLog(level) {
 		if len(args) == 0 {
 			// No arguments provided
 			return
 		}
 
-		// Format the message based on the number of arguments
-		var msgStr string
-		if len(args) == 1 {
-			// Single argument case (backwards compatibility)
-			msgStr = messageToString(args[0])
-		} else {
-			// Multiple arguments case
-			msgParts := make([]string, len(args))
-			for i, arg := range args {
-				msgParts[i] = messageToString(arg)
-			}
-			msgStr = strings.Join(msgParts, " ")
+		msgStr := argsToString(args)
+		if !s.allowed(level, msgStr) {
+			return
 		}
-
-		formatted := l.formatLogMessage(level, msgStr)
-		fmt.Fprintln(l.Output, formatted)
+		s.emit(s.recordFromArgs(level, msgStr, args))
 	}
 }
 
 // logf outputs a formatted message if it should be logged based on level
 func (l *Logger) logf(level LogLevel, format string, args ...any) {
-	if l.shouldLog(level) {
-		l.log(level, fmt.Sprintf(format, args...))
+	if s := l.snapshot(); s.shouldLog(level) && s.allowed(level, format) {
+		s.emit(s.recordFromArgs(level, fmt.Sprintf(format, args...), args))
 	}
 }
 
+// recordFromArgs builds a Record for msg, collecting the errors among args
+// and the fields attached to them
+func (l *Logger) recordFromArgs(level LogLevel, msg string, args []any) Record {
+	fields := l.fields
+	var firstErr error
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			fields = mergeFields(slices.Clip(fields), ErrorFields(err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	record := l.newRecord(level, msg, fields)
+	record.Err = firstErr
+	if levelAtLeast(level, LogLevelError) && firstErr != nil {
+		record.Stack = ErrorStack(firstErr)
+	}
+	return record
+}
+
+// emit runs the hooks for a record and, unless it repeats the previous message, dispatches it
+// It must be called on a snapshot
+func (l *Logger) emit(r Record) {
+	l.runHooks(r)
+	if l.dedupe.repeated(l, r) {
+		return
+	}
+	l.dispatch(r)
+}
+
+// dispatch hands a record to the async queue, if enabled, or writes it
+// It must be called on a snapshot
+func (l *Logger) dispatch(r Record) {
+	if l.async != nil && l.async.enqueue(l, r) {
+		return
+	}
+	l.write(r)
+}
+
+// write sends a record to the logger's Output, or passes it to its Handler,
+// and to every sink that accepts its level
+func (l *Logger) write(r Record) {
+	l.writeAll(r, false)
+}
+
+// writeAll is write with the option to bypass level filtering,
+// used for the logger's own reports such as dropped message counts
+func (l *Logger) writeAll(r Record, always bool) {
+	if always || l.primaryEnabled(r.Level) {
+		if l.Handler != nil {
+			l.emitSlog(r)
+		} else if l.Output != nil {
+			l.writeOutput(r)
+		}
+	}
+
+	for _, s := range l.sinks.list() {
+		s.write(l, r, always)
+	}
+}
+
+// writeOutput formats a record and writes it to Output
+// Only the write itself holds the lock, so each line reaches Output
+// in a single, uninterrupted Write call
+func (l *Logger) writeOutput(r Record) {
+	line := l.formatter().Format(l, r)
+	mu := l.outputMutex()
+	mu.Lock()
+	defer mu.Unlock()
+	l.Output.Write(line)
+}
+
+// Log logs a message at the given level, which may be a custom registered level
+// Unlike Fatal and Panic, logging at LogLevelFatal or LogLevelPanic neither exits nor panics
+// First argument can be a string or an error, any additional arguments are appended
+func (l Logger) Log(level LogLevel, args ...any) {
+	l.log(level, args...)
+}
+
+// Logf logs a formatted message at the given level
+func (l Logger) Logf(level LogLevel, format string, args ...any) {
+	l.logf(level, format, args...)
+}
+
+// Trace logs a message at TRACE level
+// First argument can be a string or an error, any additional arguments are appended
+func (l Logger) Trace(args ...any) {
+	l.log(LogLevelTrace, args...)
+}
+
+// Tracef logs a formatted message at TRACE level
+func (l Logger) Tracef(format string, args ...any) {
+	l.logf(LogLevelTrace, format, args...)
+}
+
 // Debug logs a message at DEBUG level
 // First argument can be a string or an error, any additional arguments are appended
 func (l Logger) Debug(args ...any) {
@@ -265,6 +697,28 @@ func (l Logger) Infof(format string, args ...any) {
 	l.logf(LogLevelInfo, format, args...)
 }
 
+// Success logs a message at SUCCESS level
+// First argument can be a string or an error, any additional arguments are appended
+func (l Logger) Success(args ...any) {
+	l.log(LogLevelSuccess, args...)
+}
+
+// Successf logs a formatted message at SUCCESS level
+func (l Logger) Successf(format string, args ...any) {
+	l.logf(LogLevelSuccess, format, args...)
+}
+
+// Notice logs a message at NOTICE level
+// First argument can be a string or an error, any additional arguments are appended
+func (l Logger) Notice(args ...any) {
+	l.log(LogLevelNotice, args...)
+}
+
+// Noticef logs a formatted message at NOTICE level
+func (l Logger) Noticef(format string, args ...any) {
+	l.logf(LogLevelNotice, format, args...)
+}
+
 // Warn logs a message at WARN level
 // First argument can be a string or an error, any additional arguments are appended
 func (l Logger) Warn(args ...any) {
@@ -288,93 +742,280 @@ func (l Logger) Errorf(format string, args ...any) {
 }
 
 // Fatal logs a message at FATAL level and exits the program
+// The exit code is 1, unless one of the arguments is an error implementing ExitCoder
 // First argument can be a string or an error, any additional arguments are appended
 func (l Logger) Fatal(args ...any) {
-	l.log(LogLevelFatal, args...)
-	os.Exit(1)
+	l.fatal(args...)
 }
 
 // Fatalf logs a formatted message at FATAL level and exits the program
 func (l Logger) Fatalf(format string, args ...any) {
+	l.fatalf(format, args...)
+}
+
+// fatal logs a message at FATAL level and exits the program
+func (l *Logger) fatal(args ...any) {
+	l.log(LogLevelFatal, args...)
+	l.exit(exitCode(args))
+}
+
+// fatalf logs a formatted message at FATAL level and exits the program
+func (l *Logger) fatalf(format string, args ...any) {
 	l.logf(LogLevelFatal, format, args...)
-	os.Exit(1)
+	l.exit(exitCode(args))
+}
+
+// Panic logs a message at PANIC level, then panics with it wrapped in a *ContextError
+// Deferred calls still run, and a recovering caller can inspect the error with errors.As
+// First argument can be a string or an error, any additional arguments are appended
+func (l Logger) Panic(args ...any) {
+	panic(l.panicArgs(args))
+}
+
+// Panicf logs a formatted message at PANIC level, then panics with it wrapped in a *ContextError
+func (l Logger) Panicf(format string, args ...any) {
+	panic(l.logPanic(fmt.Sprintf(format, args...), args))
+}
+
+// panicArgs logs args at PANIC level and returns the error to panic with
+// Like the other levels, nothing is logged without arguments, but the caller still panics
+func (l *Logger) panicArgs(args []any) error {
+	if len(args) == 0 {
+		l.Flush()
+		return l.snapshot().newContextError(errors.New("panic"))
+	}
+	return l.logPanic(argsToString(args), args)
+}
+
+// logPanic logs msg at PANIC level and returns the error to panic with
+// The error wraps the first error among args, if any, with the logger's context
+func (l *Logger) logPanic(msg string, args []any) error {
+	s := l.snapshot()
+	var err error
+	for _, arg := range args {
+		if e, ok := arg.(error); ok {
+			err = e
+			break
+		}
+	}
+	switch {
+	case err == nil:
+		err = errors.New(msg)
+	case err.Error() != msg:
+		err = &messageError{msg: msg, err: err}
+	}
+
+	wrapped := s.newContextError(err)
+	if s.shouldLog(LogLevelPanic) && s.allowed(LogLevelPanic, msg) {
+		s.emit(s.recordFromArgs(LogLevelPanic, msg, []any{wrapped}))
+	}
+	// The panic may not be recovered, so write queued messages first
+	l.Flush()
+	return wrapped
+}
+
+// labelFor returns the label text for the given level
+func labelFor(level LogLevel) string {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	return labels[level]
 }
 
 // formatLabel returns a formatted label string for the given level
 func formatLabel(level LogLevel, noColor bool) string {
-	labelText := labels[level]
+	labelText := labelFor(level)
 
 	if noColor {
 		return fmt.Sprintf("[%s] ", labelText)
 	}
 
-	return labelColors[level].Sprintf("[%s] ", labelText)
+	levelsMu.RLock()
+	labelColor := labelColors[level]
+	levelsMu.RUnlock()
+	if labelColor == nil {
+		return fmt.Sprintf("[%s] ", labelText)
+	}
+
+	// Copy the color so forcing it on does not touch the shared
+	// label colors or the process-wide color.NoColor setting
+	c := *labelColor
+	c.EnableColor()
+	return c.Sprintf("[%s] ", labelText)
 }
 
 // Global convenience functions that use the default logger
 
+// Log logs a message at the given level using the global logger
+// First argument can be a string or an error, any additional arguments are appended
+func Log(level LogLevel, args ...any) { Global().log(level, args...) }
+
+// Logf logs a formatted message at the given level using the global logger
+func Logf(level LogLevel, format string, vals ...any) { Global().logf(level, format, vals...) }
+
+// Trace logs a message at TRACE level using the global logger
+// First argument can be a string or an error, any additional arguments are appended
+func Trace(args ...any) { Global().log(LogLevelTrace, args...) }
+
+// Tracef logs a formatted message at TRACE level using the global logger
+func Tracef(format string, vals ...any) { Global().logf(LogLevelTrace, format, vals...) }
+
 // Debug logs a message at DEBUG level using the global logger
 // First argument can be a string or an error, any additional arguments are appended
-func Debug(args ...any) { G.Debug(args...) }
+func Debug(args ...any) { Global().log(LogLevelDebug, args...) }
 
 // Debugf logs a formatted message at DEBUG level using the global logger
-func Debugf(format string, vals ...any) { G.Debugf(format, vals...) }
+func Debugf(format string, vals ...any) { Global().logf(LogLevelDebug, format, vals...) }
 
 // Info logs a message at INFO level using the global logger
 // First argument can be a string or an error, any additional arguments are appended
-func Info(args ...any) { G.Info(args...) }
+func Info(args ...any) { Global().log(LogLevelInfo, args...) }
 
 // Infof logs a formatted message at INFO level using the global logger
-func Infof(format string, vals ...any) { G.Infof(format, vals...) }
+func Infof(format string, vals ...any) { Global().logf(LogLevelInfo, format, vals...) }
+
+// Success logs a message at SUCCESS level using the global logger
+// First argument can be a string or an error, any additional arguments are appended
+func Success(args ...any) { Global().log(LogLevelSuccess, args...) }
+
+// Successf logs a formatted message at SUCCESS level using the global logger
+func Successf(format string, vals ...any) { Global().logf(LogLevelSuccess, format, vals...) }
+
+// Notice logs a message at NOTICE level using the global logger
+// First argument can be a string or an error, any additional arguments are appended
+func Notice(args ...any) { Global().log(LogLevelNotice, args...) }
+
+// Noticef logs a formatted message at NOTICE level using the global logger
+func Noticef(format string, vals ...any) { Global().logf(LogLevelNotice, format, vals...) }
 
 // Warn logs a message at WARN level using the global logger
 // First argument can be a string or an error, any additional arguments are appended
-func Warn(args ...any) { G.Warn(args...) }
+func Warn(args ...any) { Global().log(LogLevelWarn, args...) }
 
 // Warnf logs a formatted message at WARN level using the global logger
-func Warnf(format string, vals ...any) { G.Warnf(format, vals...) }
+func Warnf(format string, vals ...any) { Global().logf(LogLevelWarn, format, vals...) }
 
 // Error logs a message at ERROR level using the global logger
 // First argument can be a string or an error, any additional arguments are appended
-func Error(args ...any) { G.Error(args...) }
+func Error(args ...any) { Global().log(LogLevelError, args...) }
 
 // Errorf logs a formatted message at ERROR level using the global logger
-func Errorf(format string, vals ...any) { G.Errorf(format, vals...) }
+func Errorf(format string, vals ...any) { Global().logf(LogLevelError, format, vals...) }
 
 // Fatal logs a message at FATAL level and exits the program using the global logger
 // First argument can be a string or an error, any additional arguments are appended
-func Fatal(args ...any) { G.Fatal(args...) }
+func Fatal(args ...any) { Global().fatal(args...) }
 
 // Fatalf logs a formatted message at FATAL level and exits the program using the global logger
-func Fatalf(format string, vals ...any) { G.Fatalf(format, vals...) }
+func Fatalf(format string, vals ...any) { Global().fatalf(format, vals...) }
+
+// Panic logs a message at PANIC level using the global logger, then panics
+// First argument can be a string or an error, any additional arguments are appended
+func Panic(args ...any) { panic(Global().panicArgs(args)) }
+
+// Panicf logs a formatted message at PANIC level using the global logger, then panics
+func Panicf(format string, vals ...any) { panic(Global().logPanic(fmt.Sprintf(format, vals...), vals)) }
+
+// Flush waits until all queued messages from the global logger have been written
+func Flush() { Global().Flush() }
+
+// Close flushes queued messages from the global logger and stops its background goroutine
+func Close() { Global().Close() }
 
 // Context returns the current context string from the global logger
-func Context() string { return G.Context() }
+func Context() string { return Global().contextString() }
 
 // SetContext sets a single context value for the global logger
-func SetContext(context string) { G = G.SetContext(context) }
+func SetContext(context string) {
+	updateGlobal(func(l *Logger) *Logger { return l.setContext(context) })
+}
 
 // ClearContext removes all context from the global logger
-func ClearContext() { G.ClearContext() }
+func ClearContext() {
+	configureGlobal(func(l *Logger) *Logger {
+		l.ClearContext()
+		return l
+	})
+}
 
 // Add returns a copy of the global logger with additional context
-func Add(context string) Logger { return G.Add(context) }
+func Add(context string) Logger { return Global().add(context) }
+
+// With returns a copy of the global logger with an additional field
+func With(key string, value any) Logger { return Global().withFields(Field{Key: key, Value: value}) }
+
+// WithFields returns a copy of the global logger with additional fields
+func WithFields(fields ...Field) Logger { return Global().withFields(fields...) }
 
 // Wrap wraps an error with the current context from the global logger
 // If a string is provided, it will be converted to an error
-func Wrap(val any) error { return G.Wrap(val) }
+func Wrap(val any) error { return Global().wrap(val) }
 
 // EnableColors enables colored output for the global logger
-func EnableColors() { G.EnableColors() }
+func EnableColors() { configureGlobal((*Logger).EnableColors) }
+
+// DisableColors disables colored output for the global logger
+func DisableColors() { configureGlobal((*Logger).DisableColors) }
 
 // SetContextSeparator sets the separator used for joining context elements for the global logger
-func SetContextSeparator(separator string) { G = G.SetContextSeparator(separator) }
+func SetContextSeparator(separator string) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetContextSeparator(separator) })
+}
+
+// SetFormatter sets the Formatter used to render log messages for the global logger
+func SetFormatter(f Formatter) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetFormatter(f) })
+}
+
+// SetHandler routes log messages from the global logger into a slog.Handler
+func SetHandler(h slog.Handler) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetHandler(h) })
+}
 
 // EnableTimestamps enables timestamps in log messages for the global logger
-func EnableTimestamps() { G = G.EnableTimestamps() }
+func EnableTimestamps() { configureGlobal((*Logger).EnableTimestamps) }
 
 // DisableTimestamps disables timestamps in log messages for the global logger
-func DisableTimestamps() { G = G.DisableTimestamps() }
+func DisableTimestamps() { configureGlobal((*Logger).DisableTimestamps) }
+
+// EnableCaller adds the logging call site to log messages for the global logger
+func EnableCaller() { configureGlobal((*Logger).EnableCaller) }
+
+// DisableCaller removes the logging call site from log messages for the global logger
+func DisableCaller() { configureGlobal((*Logger).DisableCaller) }
 
 // SetLogLevel sets the log level for the global logger
-func SetLogLevel(lvl LogLevel) { G = G.SetLogLevel(lvl) }
+func SetLogLevel(lvl LogLevel) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetLogLevel(lvl) })
+}
+
+// SetExclusive sets whether the global logger shows _only_ messages at its LogLevel
+func SetExclusive(exclusive bool) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetExclusive(exclusive) })
+}
+
+// SetOutput sets the destination for log messages from the global logger
+func SetOutput(w io.Writer) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetOutput(w) })
+}
+
+// SetSampling configures sampling of similar messages for the global logger
+func SetSampling(first, thereafter int, interval time.Duration) {
+	Global().SetSampling(first, thereafter, interval)
+}
+
+// SetRateLimit limits the number of messages per second from the global logger
+func SetRateLimit(perSecond float64, burst int) { Global().SetRateLimit(perSecond, burst) }
+
+// EnableDedupe collapses consecutive identical messages from the global logger
+func EnableDedupe(timeout time.Duration) { Global().EnableDedupe(timeout) }
+
+// DisableDedupe stops collapsing identical messages from the global logger
+func DisableDedupe() { Global().DisableDedupe() }
+
+// SetExitFunc sets the function the global logger's Fatal and Fatalf call to end the program
+func SetExitFunc(fn func(int)) {
+	configureGlobal(func(l *Logger) *Logger { return l.SetExitFunc(fn) })
+}
+
+// AddHook runs fn for every message logged by the global logger at one of levels
+func AddHook(levels []LogLevel, fn func(Record) error) { Global().AddHook(levels, fn) }
@@ -6,7 +6,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+
+	"github.com/fatih/color"
 )
 
 func TestLogLevels(t *testing.T) {
@@ -17,6 +21,7 @@ func TestLogLevels(t *testing.T) {
 		LogLevelWarn,
 		LogLevelError,
 		LogLevelFatal,
+		LogLevelPanic,
 	}
 
 	for i, level := range levels {
@@ -32,6 +37,7 @@ func TestLogLevels(t *testing.T) {
 		LogLevelWarn:  "WRN",
 		LogLevelError: "ERR",
 		LogLevelFatal: "FATAL",
+		LogLevelPanic: "PANIC",
 	}
 
 	for level, expectedLabel := range expectedLabels {
@@ -391,9 +397,9 @@ func TestColorControl(t *testing.T) {
 
 func TestGlobalFunctions(t *testing.T) {
 	// Save original global logger and restore after test
-	originalG := G
+	originalG := Global()
 	defer func() {
-		G = originalG
+		SetGlobal(originalG)
 	}()
 
 	// Create a test logger
@@ -558,7 +564,7 @@ func TestGlobalFunctions(t *testing.T) {
 	SetContext("test-context")
 	addLogger := Add("additional-context")
 	// We need to update the global logger so our context changes are reflected
-	G = &addLogger
+	SetGlobal(&addLogger)
 	customSepContext := Context()
 	expectedCustomSep := "test-context: additional-context"
 	if customSepContext != expectedCustomSep {
@@ -663,9 +669,9 @@ func TestMessageToString(t *testing.T) {
 
 func TestTimestampFunctionality(t *testing.T) {
 	// Save original global logger and restore after test
-	originalG := G
+	originalG := Global()
 	defer func() {
-		G = originalG
+		SetGlobal(originalG)
 	}()
 
 	// Create a test logger
@@ -713,18 +719,363 @@ func TestTimestampFunctionality(t *testing.T) {
 	testLogger.SetAsGlobal()
 
 	DisableTimestamps() // Make sure timestamps are disabled initially
-	if G.ShowTimestamps {
+	if Global().ShowTimestamps {
 		t.Errorf("Global logger should have timestamps disabled after DisableTimestamps()")
 	}
 
 	EnableTimestamps()
-	if !G.ShowTimestamps {
+	if !Global().ShowTimestamps {
 		t.Errorf("Global logger should have timestamps enabled after EnableTimestamps()")
 	}
 
 	DisableTimestamps()
-	if G.ShowTimestamps {
+	if Global().ShowTimestamps {
 		t.Errorf("Global logger should have timestamps disabled after DisableTimestamps()")
 	}
 }
 
+func TestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("svc")
+	logger.Output = &buf
+	logger.Level = LogLevelDebug
+
+	// Test With and WithFields
+	fieldLogger := logger.With("user", "alice")
+	fieldLogger = fieldLogger.WithFields(Field{Key: "attempt", Value: 3})
+	if len(logger.Fields()) != 0 {
+		t.Errorf("Expected parent logger to have no fields, got %v", logger.Fields())
+	}
+	expectedFields := []Field{{Key: "user", Value: "alice"}, {Key: "attempt", Value: 3}}
+	if fmt.Sprint(fieldLogger.Fields()) != fmt.Sprint(expectedFields) {
+		t.Errorf("Expected fields %v, got %v", expectedFields, fieldLogger.Fields())
+	}
+
+	// Test that sibling loggers do not share fields
+	a := fieldLogger.With("a", 1)
+	b := fieldLogger.With("b", 2)
+	if len(a.Fields()) != 3 || a.Fields()[2].Key != "a" || b.Fields()[2].Key != "b" {
+		t.Errorf("Expected sibling loggers to have independent fields, got %v and %v", a.Fields(), b.Fields())
+	}
+
+	// Test that a later field replaces an earlier one with the same key
+	var replaced bytes.Buffer
+	retry := fieldLogger.WithFields(Field{Key: "attempt", Value: 4}, Field{Key: "k", Value: 1}, Field{Key: "k", Value: 2})
+	retry.SetOutput(&replaced)
+	retry.Info("retrying")
+	if replaced.String() != "[INF] svc | retrying user=alice attempt=4 k=2\n" {
+		t.Errorf("Expected replaced fields, got %q", replaced.String())
+	}
+	retry.SetFormatter(JSONFormatter{})
+	replaced.Reset()
+	retry.Info("retrying")
+	if strings.Count(replaced.String(), `"k":`) != 1 || !strings.Contains(replaced.String(), `"k":2`) {
+		t.Errorf("Expected a single k key in JSON, got %q", replaced.String())
+	}
+	if fieldLogger.Fields()[1].Value != 3 {
+		t.Errorf("Expected the parent's field to be unchanged, got %v", fieldLogger.Fields())
+	}
+
+	// Test that fields are rendered after the message
+	fieldLogger.Info("logged in")
+	expected := "[INF] svc | logged in user=alice attempt=3\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	// Test that Wrap carries fields
+	err := fieldLogger.Wrap("denied")
+	if err.Error() != "svc | denied" {
+		t.Errorf("Expected wrapped error text to be unchanged, got %q", err.Error())
+	}
+	if fmt.Sprint(ErrorFields(err)) != fmt.Sprint(expectedFields) {
+		t.Errorf("Expected wrapped error fields %v, got %v", expectedFields, ErrorFields(err))
+	}
+
+	// Test that logging a wrapped error includes its fields
+	buf.Reset()
+	logger.Error(fmt.Errorf("outer: %w", err))
+	expected = "[ERR] svc | outer: svc | denied user=alice attempt=3\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	// Test that errors without fields are returned as before
+	if ErrorFields(logger.Wrap("plain")) != nil {
+		t.Errorf("Expected no fields on error wrapped by a logger without fields")
+	}
+}
+
+// serialWriter is a writer that is not safe for concurrent use
+// and records whether Write was ever called concurrently
+type serialWriter struct {
+	active   atomic.Bool
+	overlaps atomic.Int32
+	buf      bytes.Buffer
+}
+
+func (w *serialWriter) Write(p []byte) (int, error) {
+	if !w.active.CompareAndSwap(false, true) {
+		w.overlaps.Add(1)
+		return len(p), nil
+	}
+	defer w.active.Store(false)
+	return w.buf.Write(p)
+}
+
+func TestConcurrentLogging(t *testing.T) {
+	w := &serialWriter{}
+	logger := DefaultLogger().SetContext("svc")
+	logger.Output = w
+	logger.Level = LogLevelDebug
+
+	const goroutines = 16
+	const messages = 200
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sub := logger.Add(fmt.Sprintf("worker-%d", i))
+			for j := 0; j < messages; j++ {
+				sub.Errorf("message %d", j)
+				logger.Error("shared logger", j)
+			}
+		}(i)
+	}
+
+	// Reconfigure the logger while the workers are logging through it
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < messages; j++ {
+			logger.SetLogLevel(LogLevelDebug).EnableTimestamps().SetExclusive(false)
+			logger.SetContextSeparator(" | ").DisableTimestamps().EnableCaller().DisableCaller()
+			logger.SetOutput(w)
+			_ = logger.Context()
+			_ = logger.Wrap("wrapped")
+		}
+	}()
+	wg.Wait()
+
+	if n := w.overlaps.Load(); n != 0 {
+		t.Errorf("Expected writes to never overlap, got %d overlapping writes", n)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*messages*2 {
+		t.Errorf("Expected %d lines, got %d", goroutines*messages*2, len(lines))
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "[ERR] svc | ") || strings.Count(line, "[ERR]") != 1 {
+			t.Fatalf("Expected every line to be a single complete message, got %q", line)
+		}
+	}
+}
+
+func TestConcurrentGlobalLogger(t *testing.T) {
+	originalG := Global()
+	defer SetGlobal(originalG)
+
+	w := &serialWriter{}
+	base := DefaultLogger()
+	base.Output = w
+	base.Level = LogLevelDebug
+	SetGlobal(base)
+
+	const goroutines = 8
+	const messages = 200
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < messages; j++ {
+				Info("global message", j)
+				Errorf("global formatted %d", j)
+				_ = Wrap("global wrapped")
+				_ = Context()
+				Add("worker").Info("added context")
+				With("j", j).Warn("with field")
+				Global().Info("through Global")
+				Global().With("j", j).Error("through Global with field")
+			}
+		}()
+	}
+
+	// Reconfigure and replace the global logger while others log
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < messages; j++ {
+			SetContext(fmt.Sprintf("ctx-%d", j))
+			SetLogLevel(LogLevelDebug)
+			EnableTimestamps()
+			DisableTimestamps()
+			SetContextSeparator(" | ")
+			EnableCaller()
+			DisableCaller()
+			SetExclusive(false)
+			SetOutput(w)
+			if j%50 == 0 {
+				base.SetAsGlobal()
+			}
+		}
+	}()
+	wg.Wait()
+
+	if n := w.overlaps.Load(); n != 0 {
+		t.Errorf("Expected writes to never overlap, got %d overlapping writes", n)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\n"), "\n")
+	if len(lines) != goroutines*messages*6 {
+		t.Errorf("Expected %d lines, got %d", goroutines*messages*6, len(lines))
+	}
+}
+
+func TestSetGlobal(t *testing.T) {
+	originalG := Global()
+	defer SetGlobal(originalG)
+
+	logger := DefaultLogger()
+	SetGlobal(logger)
+	if Global() != logger {
+		t.Errorf("Expected Global to return the logger passed to SetGlobal")
+	}
+
+	// Test that SetContext replaces the global logger without changing the previous one
+	SetContext("global")
+	if Global() == logger || logger.Context() != "" || Context() != "global" {
+		t.Errorf("Expected SetContext to install a copy with the new context")
+	}
+
+	// Test that the setters install a copy instead of changing the stored logger
+	stored := Global()
+	SetLogLevel(LogLevelDebug)
+	ClearContext()
+	if Global() == stored || stored.Level != LogLevelError || stored.Context() != "global" {
+		t.Errorf("Expected the setters to leave the previous global logger unchanged")
+	}
+	if Global().Level != LogLevelDebug || Context() != "" {
+		t.Errorf("Expected the setters to configure the new global logger")
+	}
+
+	// Test that nil resets the global logger
+	SetGlobal(nil)
+	if Global() == nil || Context() != "" {
+		t.Errorf("Expected SetGlobal(nil) to install a new default logger")
+	}
+}
+
+func TestPerLoggerColors(t *testing.T) {
+	originalNoColor := color.NoColor
+	defer func() { color.NoColor = originalNoColor }()
+
+	var consoleBuf, fileBuf bytes.Buffer
+	console := DefaultLogger().EnableColors()
+	console.Output = &consoleBuf
+
+	// Creating and configuring a plain logger must not affect the console logger
+	file := DefaultLogger().DisableColors()
+	file.Output = &fileBuf
+
+	for _, noColor := range []bool{true, false} {
+		color.NoColor = noColor
+		consoleBuf.Reset()
+		fileBuf.Reset()
+
+		console.Error("colored")
+		file.Error("plain")
+
+		if !strings.HasPrefix(consoleBuf.String(), "\x1b[31m[ERR] \x1b[0m") {
+			t.Errorf("Expected colored label with color.NoColor=%v, got %q", noColor, consoleBuf.String())
+		}
+		if fileBuf.String() != "[ERR] plain\n" {
+			t.Errorf("Expected plain output with color.NoColor=%v, got %q", noColor, fileBuf.String())
+		}
+	}
+
+	if color.NoColor != false {
+		t.Errorf("Expected loggers not to modify color.NoColor")
+	}
+}
+
+func TestPanic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("DB").SetOutput(&buf)
+	cause := errors.New("connection lost")
+
+	recovered := func(fn func()) (value any) {
+		defer func() { value = recover() }()
+		fn()
+		return nil
+	}
+
+	value := recovered(func() { logger.Panicf("query failed: %v", cause) })
+	if buf.String() != "[PANIC] DB | query failed: connection lost\n" {
+		t.Errorf("Expected panic message to be logged, got %q", buf.String())
+	}
+
+	err, ok := value.(error)
+	if !ok {
+		t.Fatalf("Expected to recover an error, got %T", value)
+	}
+	var ctxErr *ContextError
+	if !errors.As(err, &ctxErr) {
+		t.Fatalf("Expected errors.As to find a *ContextError")
+	}
+	if ctxErr.Error() != "DB | query failed: connection lost" {
+		t.Errorf("Expected panic error text, got %q", ctxErr.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected the panic error to wrap the cause")
+	}
+
+	// Test a plain message, and that panics are logged even when the level is FATAL
+	buf.Reset()
+	logger.SetLogLevel(LogLevelFatal)
+	value = recovered(func() { logger.Panic("invariant", "broken") })
+	if buf.String() != "[PANIC] DB | invariant broken\n" {
+		t.Errorf("Expected panic message to be logged, got %q", buf.String())
+	}
+	if err, ok := value.(*ContextError); !ok || err.Unwrap().Error() != "invariant broken" {
+		t.Errorf("Expected a *ContextError for the message, got %v", value)
+	}
+
+	// Test that Panic without arguments logs nothing but still panics
+	buf.Reset()
+	value = recovered(func() { logger.Panic() })
+	if buf.Len() != 0 {
+		t.Errorf("Expected no log line without arguments, got %q", buf.String())
+	}
+	if err, ok := value.(*ContextError); !ok || err.Error() != "DB | panic" {
+		t.Errorf("Expected a default panic error, got %v", value)
+	}
+}
+
+func TestChainedCalls(t *testing.T) {
+	var buf bytes.Buffer
+	old := Global()
+	defer SetGlobal(old)
+	SetGlobal(DefaultLogger().SetOutput(&buf).SetLogLevel(LogLevelInfo))
+
+	// These must compile: Add, With and WithFields return Logger values,
+	// which are not addressable when calls are chained
+	Add("x").Info("hi")
+	DefaultLogger().SetOutput(&buf).Add("y").Error("e")
+	Add("z").With("k", 1).WithFields(Field{Key: "n", Value: 2}).Warnf("%s", "w")
+	err := Add("z").Wrap("wrapped")
+	ctx := Add("a").Add("b").Context()
+	fields := With("k", "v").Fields()
+
+	expected := "[INF] x | hi\n[ERR] y | e\n[WRN] z | w k=1 n=2\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+	if err.Error() != "z | wrapped" || ctx != "a | b" || len(fields) != 1 {
+		t.Errorf("Expected chained Wrap, Context and Fields to work, got %q, %q, %v", err, ctx, fields)
+	}
+}
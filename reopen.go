@@ -0,0 +1,124 @@
+package logerr
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// ReopenFile is an io.WriteCloser, meant to be used as a Logger's Output,
+// that can reopen its path after an external tool such as logrotate has
+// moved the file away. Writes and reopens are serialized, so no line is
+// lost or split across the old and new files
+type ReopenFile struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// NewReopenFile opens path for appending, creating it if needed
+func NewReopenFile(path string) (*ReopenFile, error) {
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ReopenFile{path: path, file: file}, nil
+}
+
+// Write writes p to the currently open file
+func (f *ReopenFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	return f.file.Write(p)
+}
+
+// Reopen opens the path again and swaps it in for the current file
+// If the path cannot be opened, the current file stays in use
+func (f *ReopenFile) Reopen() error {
+	file, err := openAppend(f.path)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	old := f.file
+	if old == nil {
+		f.mu.Unlock()
+		file.Close()
+		return os.ErrClosed
+	}
+	f.file = file
+	f.mu.Unlock()
+
+	return old.Close()
+}
+
+// ReopenOnSignal reopens the file whenever one of sigs is received
+// Without arguments it listens for SIGHUP, where the platform supports it
+func (f *ReopenFile) ReopenOnSignal(sigs ...os.Signal) {
+	if len(sigs) == 0 {
+		sigs = reopenSignals
+	}
+	if len(sigs) == 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.signals != nil {
+		signal.Notify(f.signals, sigs...)
+		return
+	}
+
+	f.signals = make(chan os.Signal, 1)
+	f.done = make(chan struct{})
+	signal.Notify(f.signals, sigs...)
+	go f.watch(f.signals, f.done)
+}
+
+// watch reopens the file for every received signal until done is closed
+func (f *ReopenFile) watch(signals <-chan os.Signal, done <-chan struct{}) {
+	for {
+		select {
+		case <-signals:
+			if err := f.Reopen(); err != nil {
+				// Reported directly, since the logger may be writing to this file
+				fmt.Fprintf(os.Stderr, "logerr: reopen %s: %v\n", f.path, err)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// Close stops signal handling and closes the current file
+func (f *ReopenFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.signals != nil {
+		signal.Stop(f.signals)
+		close(f.done)
+		f.signals = nil
+	}
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// openAppend opens path for appending, creating it if needed
+func openAppend(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
@@ -0,0 +1,127 @@
+package logerr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReopenFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := NewReopenFile(name)
+	if err != nil {
+		t.Fatalf("NewReopenFile failed: %v", err)
+	}
+
+	logger := DefaultLogger().SetOutput(f)
+	logger.Error("before rotation")
+
+	// Simulate logrotate moving the file away
+	rotated := name + ".1"
+	if err := os.Rename(name, rotated); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	logger.Error("still in old file")
+
+	if err := f.Reopen(); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	logger.Error("after rotation")
+
+	if got := readFile(t, rotated); got != "[ERR] before rotation\n[ERR] still in old file\n" {
+		t.Errorf("Unexpected rotated file contents %q", got)
+	}
+	if got := readFile(t, name); got != "[ERR] after rotation\n" {
+		t.Errorf("Unexpected new file contents %q", got)
+	}
+
+	// Test that a failed reopen keeps the current file
+	os.Remove(name)
+	os.Mkdir(name, 0o755)
+	if err := f.Reopen(); err == nil {
+		t.Errorf("Expected Reopen to fail when the path is a directory")
+	}
+	if _, err := f.Write([]byte("kept\n")); err != nil {
+		t.Errorf("Expected writes to continue after a failed reopen, got %v", err)
+	}
+
+	// Test that the file is unusable after Close
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := f.Write([]byte("closed\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected os.ErrClosed after Close, got %v", err)
+	}
+	if err := f.Reopen(); err == nil {
+		t.Errorf("Expected Reopen to fail after Close")
+	}
+}
+
+func TestReopenFileConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := NewReopenFile(name)
+	if err != nil {
+		t.Fatalf("NewReopenFile failed: %v", err)
+	}
+
+	logger := DefaultLogger().SetOutput(f)
+	var wg sync.WaitGroup
+	var stop atomic.Bool
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				logger.Error("concurrent message")
+			}
+		}()
+	}
+
+	// Rotate repeatedly while the writers are running
+	var rotations []string
+	var rotator sync.WaitGroup
+	rotator.Add(1)
+	go func() {
+		defer rotator.Done()
+		for i := 0; !stop.Load(); i++ {
+			rotated := filepath.Join(dir, fmt.Sprintf("app.log.%d", i+1))
+			if err := os.Rename(name, rotated); err != nil {
+				t.Errorf("Rename failed: %v", err)
+				return
+			}
+			rotations = append(rotations, rotated)
+			if err := f.Reopen(); err != nil {
+				t.Errorf("Reopen failed: %v", err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	stop.Store(true)
+	rotator.Wait()
+	f.Close()
+
+	var lines int
+	for _, path := range append(rotations, name) {
+		content := readFile(t, path)
+		for _, line := range strings.SplitAfter(content, "\n") {
+			if line == "" {
+				continue
+			}
+			if line != "[ERR] concurrent message\n" {
+				t.Fatalf("Expected only complete lines in %s, got %q", path, line)
+			}
+			lines++
+		}
+	}
+	if lines != 800 {
+		t.Errorf("Expected 800 lines across all files, got %d", lines)
+	}
+}
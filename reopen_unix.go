@@ -0,0 +1,11 @@
+//go:build !windows
+
+package logerr
+
+import (
+	"os"
+	"syscall"
+)
+
+// reopenSignals are the signals ReopenFile listens for by default
+var reopenSignals = []os.Signal{syscall.SIGHUP}
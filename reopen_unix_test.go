@@ -0,0 +1,43 @@
+//go:build !windows
+
+package logerr
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReopenOnSignal(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := NewReopenFile(name)
+	if err != nil {
+		t.Fatalf("NewReopenFile failed: %v", err)
+	}
+	defer f.Close()
+	f.ReopenOnSignal()
+
+	if err := os.Rename(name, name+".1"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("Sending SIGHUP failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !fileExists(name) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected SIGHUP to reopen %s", name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	logger := DefaultLogger().SetOutput(f)
+	logger.Error("after signal")
+	if got := readFile(t, name); got != "[ERR] after signal\n" {
+		t.Errorf("Expected new file to receive writes, got %q", got)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package logerr
+
+import "os"
+
+// reopenSignals are the signals ReopenFile listens for by default
+// Windows has no SIGHUP, so Reopen must be called explicitly
+var reopenSignals []os.Signal
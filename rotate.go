@@ -0,0 +1,273 @@
+package logerr
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp layout used in rotated file names
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is an io.WriteCloser, meant to be used as a Logger's Output,
+// that rotates the file it writes to by size and/or age.
+// Rotated files are renamed to name-<timestamp>.ext next to the original,
+// optionally gzipped, and pruned by count and age.
+// It is safe for concurrent use
+type RotatingFile struct {
+	// Filename is the file to write to
+	Filename string
+
+	// MaxSize is the size in bytes a file may reach before it is rotated
+	// Zero disables size-based rotation
+	MaxSize int64
+
+	// Interval is how long a file is written to before it is rotated
+	// Zero disables time-based rotation
+	Interval time.Duration
+
+	// MaxBackups is the number of rotated files to keep
+	// Zero keeps all of them
+	MaxBackups int
+
+	// MaxAge is how long rotated files are kept
+	// Zero keeps them regardless of age
+	MaxAge time.Duration
+
+	// Compress gzips rotated files
+	Compress bool
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	timeSource
+}
+
+// NewRotatingFile opens filename for appending, creating it if needed,
+// and returns a RotatingFile that rotates it at maxSize bytes
+func NewRotatingFile(filename string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{Filename: filename, MaxSize: maxSize, MaxBackups: maxBackups}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes p to the current file, rotating it first if needed
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the current file, moves it to a backup and opens a new one
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	return f.rotate()
+}
+
+// Close closes the current file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// shouldRotate reports whether writing n more bytes requires a rotation
+func (f *RotatingFile) shouldRotate(n int64) bool {
+	if f.MaxSize > 0 && f.size > 0 && f.size+n > f.MaxSize {
+		return true
+	}
+	return f.Interval > 0 && f.size > 0 && f.clock().Sub(f.openedAt) >= f.Interval
+}
+
+// open opens the file for appending, creating its directory if needed
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.Filename), 0o755); err != nil {
+		return err
+	}
+	file, err := openAppend(f.Filename)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.clock()
+	return nil
+}
+
+// rotate moves the current file to a timestamped backup, opens a new file
+// and removes backups beyond MaxBackups or older than MaxAge
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	backup := f.backupName(f.clock())
+	if err := os.Rename(f.Filename, backup); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.Compress {
+		if err := compressFile(backup); err != nil {
+			return err
+		}
+	}
+	return f.prune()
+}
+
+// backupName returns an unused name for a backup rotated at t
+func (f *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := f.backupPattern()
+	for {
+		name := prefix + t.Format(backupTimeFormat) + ext
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// backupPattern returns the path prefix and extension shared by all backups
+func (f *RotatingFile) backupPattern() (string, string) {
+	ext := filepath.Ext(f.Filename)
+	return strings.TrimSuffix(f.Filename, ext) + "-", ext
+}
+
+// backup is a rotated file and the time it was rotated
+type backup struct {
+	path      string
+	rotatedAt time.Time
+}
+
+// backups returns the existing backups, newest first
+func (f *RotatingFile) backups() ([]backup, error) {
+	prefix, ext := f.backupPattern()
+	entries, err := os.ReadDir(filepath.Dir(f.Filename))
+	if err != nil {
+		return nil, err
+	}
+
+	// Match names rather than joined paths, which are cleaned and may no
+	// longer start with a prefix built from a path such as ./logs/app.log
+	var result []backup
+	for _, entry := range entries {
+		path := filepath.Join(filepath.Dir(f.Filename), entry.Name())
+		stamp, ok := strings.CutPrefix(entry.Name(), filepath.Base(prefix))
+		if !ok || entry.IsDir() {
+			continue
+		}
+		stamp = strings.TrimSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		result = append(result, backup{path: path, rotatedAt: t})
+	}
+
+	slices.SortFunc(result, func(a, b backup) int { return b.rotatedAt.Compare(a.rotatedAt) })
+	return result, nil
+}
+
+// prune removes backups beyond MaxBackups or older than MaxAge
+func (f *RotatingFile) prune() error {
+	if f.MaxBackups <= 0 && f.MaxAge <= 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	cutoff := f.clock().Add(-f.MaxAge)
+	for i, b := range backups {
+		tooMany := f.MaxBackups > 0 && i >= f.MaxBackups
+		tooOld := f.MaxAge > 0 && b.rotatedAt.Before(cutoff)
+		if tooMany || tooOld {
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// compressFile gzips path into path.gz and removes the original
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,225 @@
+package logerr
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for rotation tests
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestRotatingFileBySize(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := NewRotatingFile(name, 20, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer f.Close()
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}
+	f.now = clock.now
+
+	logger := DefaultLogger().SetOutput(f)
+	for _, msg := range []string{"first", "second", "third", "fourth"} {
+		logger.Error(msg)
+		clock.advance(time.Second)
+	}
+
+	// Every line is 12-13 bytes, so each file holds one line
+	if got := readFile(t, name); got != "[ERR] fourth\n" {
+		t.Errorf("Expected current file to hold the last line, got %q", got)
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatalf("Listing backups failed: %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Expected MaxBackups to keep 2 backups, got %d", len(backups))
+	}
+	if got := readFile(t, backups[0].path); got != "[ERR] third\n" {
+		t.Errorf("Expected newest backup to hold the third line, got %q", got)
+	}
+	expected := filepath.Join(dir, "app-2024-01-01T00-00-03.000.log")
+	if backups[0].path != expected {
+		t.Errorf("Expected backup name %q, got %q", expected, backups[0].path)
+	}
+}
+
+func TestRotatingFileRelativePath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "logs"), 0o755); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd failed: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir failed: %v", err)
+	}
+	defer os.Chdir(wd)
+
+	f, err := NewRotatingFile("./logs/../logs/app.log", 20, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer f.Close()
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}
+	f.now = clock.now
+
+	logger := DefaultLogger().SetOutput(f)
+	for i := 0; i < 9; i++ {
+		logger.Errorf("line %d", i)
+		clock.advance(time.Second)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "logs"))
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("Expected the current file and 2 backups, got %d files", len(entries))
+	}
+}
+
+func TestRotatingFileByInterval(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}
+	f := &RotatingFile{
+		Filename:   filepath.Join(dir, "app.log"),
+		Interval:   time.Hour,
+		MaxAge:     30 * time.Minute,
+		Compress:   true,
+		timeSource: timeSource{clock.now},
+	}
+	defer f.Close()
+
+	for _, line := range []string{"a\n", "b\n", "c\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		clock.advance(time.Hour)
+	}
+
+	// The file written at 00:00 was rotated at 01:00 and is older than MaxAge at 02:00
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatalf("Listing backups failed: %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("Expected MaxAge to leave 1 backup, got %v", backups)
+	}
+	if !strings.HasSuffix(backups[0].path, "app-2024-01-01T02-00-00.000.log.gz") {
+		t.Errorf("Expected a gzipped backup, got %q", backups[0].path)
+	}
+
+	gzFile, err := os.Open(backups[0].path)
+	if err != nil {
+		t.Fatalf("Opening backup failed: %v", err)
+	}
+	defer gzFile.Close()
+	gz, err := gzip.NewReader(gzFile)
+	if err != nil {
+		t.Fatalf("Reading gzip failed: %v", err)
+	}
+	data, _ := io.ReadAll(gz)
+	if string(data) != "b\n" {
+		t.Errorf("Expected backup contents %q, got %q", "b\n", data)
+	}
+}
+
+func TestRotatingFileIntervalEmpty(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}
+	f := &RotatingFile{
+		Filename:   filepath.Join(dir, "app.log"),
+		Interval:   time.Hour,
+		timeSource: timeSource{clock.now},
+	}
+	defer f.Close()
+
+	// Test that a file left empty for longer than Interval is written to, not rotated
+	if err := f.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	clock.advance(2 * time.Hour)
+	if _, err := f.Write([]byte("a\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatalf("Listing backups failed: %v", err)
+	}
+	if len(backups) != 1 {
+		t.Errorf("Expected only the backup made by Rotate, got %v", backups)
+	}
+}
+
+func TestRotatingFileConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := NewRotatingFile(name, 1024, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+
+	logger := DefaultLogger().SetOutput(f)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Errorf("concurrent message %03d", j)
+			}
+		}()
+	}
+	wg.Wait()
+	f.Close()
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "app*.log"))
+	var lines int
+	for _, path := range matches {
+		for _, line := range strings.Split(strings.TrimSuffix(readFile(t, path), "\n"), "\n") {
+			if !strings.HasPrefix(line, "[ERR] concurrent message ") || len(line) != len("[ERR] concurrent message 000") {
+				t.Fatalf("Expected complete lines in %s, got %q", path, line)
+			}
+			lines++
+		}
+	}
+	if lines != 800 {
+		t.Errorf("Expected 800 lines across all files, got %d", lines)
+	}
+}
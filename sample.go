@@ -0,0 +1,244 @@
+package logerr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultReportInterval is how often suppressed counts are reported
+// when only rate limiting is enabled
+const defaultReportInterval = time.Second
+
+// maxSampleKeys bounds the number of sample windows kept between sweeps
+const maxSampleKeys = 4096
+
+// sampleKey identifies similar messages for sampling
+type sampleKey struct {
+	level    LogLevel
+	context  string
+	template string
+}
+
+// sampleWindow counts the messages seen for a key in the current interval
+type sampleWindow struct {
+	start time.Time
+	count int
+}
+
+// limiter applies sampling and rate limiting to a logger's messages
+type limiter struct {
+	mu sync.Mutex
+
+	// first messages per key are logged in every interval, then every thereafter-th
+	first      int
+	thereafter int
+	interval   time.Duration
+	windows    map[sampleKey]*sampleWindow
+
+	// a token bucket refilled at rate tokens per second, holding up to burst tokens
+	rate   float64
+	burst  int
+	tokens float64
+	filled time.Time
+
+	// suppressed counts messages dropped since the last report, total since creation
+	suppressed uint64
+	total      uint64
+	reportAt   time.Time
+
+	// timer reports the suppressed count through logger if no message arrives to do it
+	timer  *time.Timer
+	logger *Logger
+
+	timeSource
+}
+
+// allow reports whether a message from the logger snapshot l should be logged,
+// along with the number of suppressed messages due to be reported
+func (lim *limiter) allow(l *Logger, level LogLevel, context []string, template string) (bool, uint64) {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	if lim.interval <= 0 && lim.rate <= 0 {
+		return true, lim.due(lim.clock())
+	}
+
+	now := lim.clock()
+	ok := lim.sample(now, sampleKey{level, strings.Join(context, "\x00"), template}) && lim.take(now)
+	if !ok {
+		lim.suppressed++
+		lim.total++
+		if lim.reportAt.IsZero() {
+			lim.reportAt = now.Add(lim.reportInterval())
+			lim.logger = l
+			lim.timer = time.AfterFunc(lim.reportInterval(), lim.expire)
+		}
+	}
+	return ok, lim.due(now)
+}
+
+// sample counts a message against its key's window and reports whether it is sampled in
+func (lim *limiter) sample(now time.Time, key sampleKey) bool {
+	if lim.interval <= 0 {
+		return true
+	}
+	w := lim.windows[key]
+	if w == nil || now.Sub(w.start) >= lim.interval {
+		if w == nil {
+			if len(lim.windows) >= maxSampleKeys {
+				lim.sweep(now)
+			}
+			w = &sampleWindow{}
+			lim.windows[key] = w
+		}
+		w.start, w.count = now, 0
+	}
+	w.count++
+	if w.count <= lim.first {
+		return true
+	}
+	return lim.thereafter > 0 && (w.count-lim.first)%lim.thereafter == 0
+}
+
+// take refills the token bucket and removes a token, if one is available
+func (lim *limiter) take(now time.Time) bool {
+	if lim.rate <= 0 {
+		return true
+	}
+	if elapsed := now.Sub(lim.filled).Seconds(); elapsed > 0 {
+		lim.tokens = min(lim.tokens+elapsed*lim.rate, float64(lim.burst))
+	}
+	lim.filled = now
+	if lim.tokens < 1 {
+		return false
+	}
+	lim.tokens--
+	return true
+}
+
+// due returns and resets the suppressed count once its report interval has passed
+func (lim *limiter) due(now time.Time) uint64 {
+	if lim.suppressed == 0 || now.Before(lim.reportAt) {
+		return 0
+	}
+	return lim.drain(now)
+}
+
+// drain returns and resets the suppressed count, forgetting expired sample windows
+func (lim *limiter) drain(now time.Time) uint64 {
+	n := lim.suppressed
+	lim.suppressed = 0
+	lim.reportAt = time.Time{}
+	if lim.timer != nil {
+		lim.timer.Stop()
+		lim.timer = nil
+	}
+	lim.sweep(now)
+	return n
+}
+
+// expire reports the suppressed count once the report interval passes
+// without a message arriving to report it
+func (lim *limiter) expire() {
+	lim.mu.Lock()
+	lim.timer = nil
+	l := lim.logger
+	var n uint64
+	if lim.suppressed > 0 {
+		n = lim.drain(lim.clock())
+	}
+	lim.mu.Unlock()
+	if l != nil {
+		l.reportSuppressed(n)
+	}
+}
+
+// sweep forgets expired sample windows, or all of them if none have expired
+// and there are too many to keep
+func (lim *limiter) sweep(now time.Time) {
+	for key, w := range lim.windows {
+		if now.Sub(w.start) >= lim.interval {
+			delete(lim.windows, key)
+		}
+	}
+	if len(lim.windows) >= maxSampleKeys {
+		clear(lim.windows)
+	}
+}
+
+// pending returns and resets the suppressed count regardless of the report interval
+func (lim *limiter) pending() uint64 {
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	if lim.suppressed == 0 {
+		return 0
+	}
+	return lim.drain(lim.clock())
+}
+
+// reportInterval returns how often suppressed counts are reported
+func (lim *limiter) reportInterval() time.Duration {
+	if lim.interval > 0 {
+		return lim.interval
+	}
+	return defaultReportInterval
+}
+
+// allowed applies sampling and rate limiting to a message that passed the level check,
+// reporting suppressed counts when they are due
+// It must be called on a snapshot
+func (l *Logger) allowed(level LogLevel, template string) bool {
+	if level == LogLevelFatal || level == LogLevelPanic {
+		// Never hide the message explaining why the program exited or panicked
+		l.reportSuppressed(l.limits.pending())
+		return true
+	}
+	ok, n := l.limits.allow(l, level, l.context, template)
+	l.reportSuppressed(n)
+	return ok
+}
+
+// reportSuppressed writes a warning with the number of messages dropped by sampling
+// or rate limiting
+func (l *Logger) reportSuppressed(n uint64) {
+	if n > 0 {
+		l.writeAll(Record{
+			Time:    time.Now(),
+			Level:   LogLevelWarn,
+			Message: fmt.Sprintf("suppressed %d similar messages", n),
+		}, true)
+	}
+}
+
+// SetSampling logs the first messages with the same level, context and message template
+// in every interval, then every thereafter-th one. A thereafter of 0 drops the rest.
+// A non-positive interval disables sampling. Suppressed messages are reported with a
+// warning at most once per interval
+func (l *Logger) SetSampling(first, thereafter int, interval time.Duration) *Logger {
+	lim := l.limits
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.first, lim.thereafter, lim.interval = first, thereafter, interval
+	lim.windows = make(map[sampleKey]*sampleWindow)
+	return l
+}
+
+// SetRateLimit limits the logger to perSecond messages on average, with bursts of up to
+// burst messages. A non-positive perSecond disables the limit
+func (l *Logger) SetRateLimit(perSecond float64, burst int) *Logger {
+	lim := l.limits
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.rate, lim.burst = perSecond, max(burst, 1)
+	lim.tokens, lim.filled = float64(lim.burst), lim.clock()
+	return l
+}
+
+// Suppressed returns the total number of messages dropped by sampling or rate limiting
+func (l *Logger) Suppressed() uint64 {
+	lim := l.limits
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	return lim.total
+}
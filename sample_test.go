@@ -0,0 +1,93 @@
+package logerr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetOutput(&buf).SetSampling(2, 3, time.Second)
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	logger.limits.now = clock.now
+
+	// Messages sharing a template are sampled together, whatever their arguments
+	for i := 1; i <= 8; i++ {
+		logger.Errorf("query %d failed", i)
+	}
+	// A different template and a different context are sampled separately
+	logger.Error("connection reset")
+	other := logger.Add("other")
+	other.Errorf("query %d failed", 9)
+
+	expected := "[ERR] query 1 failed\n" +
+		"[ERR] query 2 failed\n" +
+		"[ERR] query 5 failed\n" +
+		"[ERR] query 8 failed\n" +
+		"[ERR] connection reset\n" +
+		"[ERR] other | query 9 failed\n"
+	if buf.String() != expected {
+		t.Errorf("Expected sampled output:\n%s\ngot:\n%s", expected, buf.String())
+	}
+	if logger.Suppressed() != 4 {
+		t.Errorf("Expected 4 suppressed messages, got %d", logger.Suppressed())
+	}
+
+	// Test that the count is reported, and the window reset, after the interval
+	buf.Reset()
+	clock.advance(time.Second)
+	logger.Errorf("query %d failed", 10)
+	expected = "[WRN] suppressed 4 similar messages\n[ERR] query 10 failed\n"
+	if buf.String() != expected {
+		t.Errorf("Expected report after the interval %q, got %q", expected, buf.String())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetOutput(&buf)
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	logger.limits.now = clock.now
+	logger.SetRateLimit(2, 3)
+
+	for i := 0; i < 5; i++ {
+		logger.Errorf("burst %d", i)
+	}
+	clock.advance(500 * time.Millisecond)
+	logger.Error("refilled")
+	logger.Error("empty")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	expected := []string{"[ERR] burst 0", "[ERR] burst 1", "[ERR] burst 2", "[ERR] refilled"}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+
+	// Test that Flush reports suppressed messages before the report interval
+	buf.Reset()
+	logger.Flush()
+	if buf.String() != "[WRN] suppressed 3 similar messages\n" {
+		t.Errorf("Expected Flush to report suppressed messages, got %q", buf.String())
+	}
+}
+
+func TestSamplingReportTimer(t *testing.T) {
+	buf := &lockedBuffer{}
+	logger := DefaultLogger().SetOutput(buf).SetSampling(1, 0, 20*time.Millisecond)
+
+	// The flood stops after the first interval's messages, and the count is still reported
+	for i := 0; i < 5; i++ {
+		logger.Error("flood")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(buf.String(), "suppressed") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	expected := "[ERR] flood\n[WRN] suppressed 4 similar messages\n"
+	if buf.String() != expected {
+		t.Errorf("Expected the timer to report suppressed messages %q, got %q", expected, buf.String())
+	}
+}
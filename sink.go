@@ -0,0 +1,123 @@
+package logerr
+
+import (
+	"io"
+	"slices"
+	"sync"
+)
+
+// Sink is an additional destination for a Logger's messages,
+// with its own level filtering, formatter and color setting
+type Sink struct {
+	// Output destination for log messages
+	Output io.Writer
+
+	// Level dictates the minimum LogLevel that will be written to this sink
+	Level LogLevel
+
+	// Exclusive dictates whether _only_ messages at Level are written to this sink
+	Exclusive bool
+
+	// Formatter renders log records for this sink
+	// Defaults to TextFormatter
+	Formatter Formatter
+
+	// NoColor disables colored output when true
+	NoColor bool
+
+	// mu guards the sink's configuration and serializes writes to Output
+	mu sync.Mutex
+}
+
+// NewSink creates a sink that writes uncolored text at level and above to w
+func NewSink(w io.Writer, level LogLevel) *Sink {
+	return &Sink{Output: w, Level: level, NoColor: true}
+}
+
+// SetLogLevel sets the minimum level written to the sink
+func (s *Sink) SetLogLevel(lvl LogLevel) *Sink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Level = lvl
+	return s
+}
+
+// SetFormatter sets the Formatter used to render messages for the sink
+func (s *Sink) SetFormatter(f Formatter) *Sink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Formatter = f
+	return s
+}
+
+// enabled reports whether the sink accepts messages at level
+func (s *Sink) enabled(level LogLevel) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return levelEnabled(s.Level, s.Exclusive, level)
+}
+
+// write formats a record for the sink and writes it, if the sink accepts its
+// level or always is set. l supplies the logger settings, such as the context
+// separator, used by formatters
+func (s *Sink) write(l *Logger, r Record, always bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !always && !levelEnabled(s.Level, s.Exclusive, r.Level) {
+		return
+	}
+
+	view := l.clone()
+	view.NoColor = s.NoColor
+	formatter := s.Formatter
+	if formatter == nil {
+		formatter = TextFormatter{}
+	}
+	s.Output.Write(formatter.Format(view, r))
+}
+
+// sinkSet is the list of sinks a logger writes to
+type sinkSet struct {
+	mu    sync.RWMutex
+	sinks []*Sink
+}
+
+// list returns the current sinks
+func (set *sinkSet) list() []*Sink {
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	return set.sinks
+}
+
+// AddSink adds a destination that receives messages alongside Output
+func (l *Logger) AddSink(s *Sink) *Logger {
+	set := l.sinks
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.sinks = append(slices.Clip(set.sinks), s)
+	return l
+}
+
+// RemoveSink removes a sink previously added with AddSink
+func (l *Logger) RemoveSink(s *Sink) *Logger {
+	set := l.sinks
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.sinks = slices.DeleteFunc(slices.Clone(set.sinks), func(x *Sink) bool { return x == s })
+	return l
+}
+
+// Sinks returns the sinks added to the logger
+func (l *Logger) Sinks() []*Sink {
+	return slices.Clone(l.sinks.list())
+}
+
+// anySinkEnabled reports whether any of the logger's sinks accepts messages at level
+func (l *Logger) anySinkEnabled(level LogLevel) bool {
+	for _, s := range l.sinks.list() {
+		if s.enabled(level) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package logerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSinks(t *testing.T) {
+	var console, file bytes.Buffer
+	logger := DefaultLogger().SetContext("API").EnableColors()
+	logger.Output = &console
+	logger.Level = LogLevelInfo
+
+	// Derived before the sink is added, and must still share it
+	auth := logger.Add("Auth")
+
+	fileSink := NewSink(&file, LogLevelDebug).SetFormatter(JSONFormatter{})
+	logger.AddSink(fileSink)
+
+	auth.Debug("debug only in file")
+	auth.Info("in both")
+
+	if strings.Contains(console.String(), "debug only in file") {
+		t.Errorf("Expected debug message to be filtered from the console, got %q", console.String())
+	}
+	if !strings.HasPrefix(console.String(), "\x1b[32m[INF] \x1b[0mAPI | Auth | in both") {
+		t.Errorf("Expected colored console output, got %q", console.String())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(file.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines in the file sink, got %q", file.String())
+	}
+	for i, expected := range []string{"DBG", "INF"} {
+		var got map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &got); err != nil {
+			t.Fatalf("Expected valid JSON, got %q: %v", lines[i], err)
+		}
+		if got["level"] != expected {
+			t.Errorf("Expected level %s, got %v", expected, got["level"])
+		}
+	}
+
+	// Test that SetContext copies share sinks and that sinks can be removed
+	file.Reset()
+	other := logger.SetContext("Other")
+	other.Debug("from copy")
+	if !strings.Contains(file.String(), "from copy") {
+		t.Errorf("Expected SetContext copy to share sinks, got %q", file.String())
+	}
+	if len(other.Sinks()) != 1 || other.Sinks()[0] != fileSink {
+		t.Errorf("Expected the copy to report the shared sink")
+	}
+
+	file.Reset()
+	auth.RemoveSink(fileSink)
+	logger.Error("after removal")
+	if file.Len() != 0 || len(logger.Sinks()) != 0 {
+		t.Errorf("Expected removed sink to receive nothing, got %q", file.String())
+	}
+}
+
+func TestSinkExclusive(t *testing.T) {
+	var warnings bytes.Buffer
+	logger := DefaultLogger()
+	logger.Output = nil
+
+	sink := NewSink(&warnings, LogLevelWarn)
+	sink.Exclusive = true
+	logger.AddSink(sink)
+
+	if logger.shouldLog(LogLevelError) {
+		t.Errorf("Expected no destination to accept errors")
+	}
+
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	if warnings.String() != "[WRN] warn\n" {
+		t.Errorf("Expected only the warning in the exclusive sink, got %q", warnings.String())
+	}
+}
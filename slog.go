@@ -0,0 +1,139 @@
+package logerr
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler that writes records through a Logger,
+// keeping its level filtering, Exclusive semantics and formatting.
+// Groups become context segments and attributes become fields
+type SlogHandler struct {
+	logger *Logger
+}
+
+// NewSlogHandler returns a slog.Handler that writes through l
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{logger: l}
+}
+
+// Enabled reports whether the logger would output messages at level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.enabled(fromSlogLevel(level))
+}
+
+// Handle writes the record through the logger
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	level := fromSlogLevel(r.Level)
+	logger := h.logger.snapshot()
+	if !logger.shouldLog(level) || !logger.allowed(level, r.Message) {
+		return nil
+	}
+
+	fields := logger.fields
+	var firstErr error
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, "", a)
+		if err, ok := a.Value.Any().(error); ok && firstErr == nil {
+			firstErr = err
+		}
+		return true
+	})
+
+	record := logger.newRecord(level, r.Message, fields)
+	record.Err = firstErr
+	if !r.Time.IsZero() {
+		record.Time = r.Time
+	}
+	record.PC = r.PC
+	logger.emit(record)
+	return nil
+}
+
+// WithAttrs returns a handler whose logger carries attrs as fields
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []Field
+	for _, a := range attrs {
+		fields = appendAttr(fields, "", a)
+	}
+	logger := h.logger.withFields(fields...)
+	return &SlogHandler{logger: &logger}
+}
+
+// WithGroup returns a handler whose logger has name added as a context segment
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	logger := h.logger.add(name)
+	return &SlogHandler{logger: &logger}
+}
+
+// appendAttr converts a slog.Attr into fields, flattening groups into dotted keys
+func appendAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+
+	return append(fields[:len(fields):len(fields)], Field{Key: prefix + a.Key, Value: a.Value.Any()})
+}
+
+// emitSlog converts a record into a slog.Record and passes it to the logger's Handler.
+// Context segments are sent as a "context" attribute and the logged error as "error"
+func (l *Logger) emitSlog(r Record) {
+	ctx := context.Background()
+	level := toSlogLevel(r.Level)
+	if !l.Handler.Enabled(ctx, level) {
+		return
+	}
+
+	sr := slog.NewRecord(r.Time, level, r.Message, r.PC)
+	if len(r.Context) > 0 {
+		sr.AddAttrs(slog.Any("context", r.Context))
+	}
+	if r.Err != nil {
+		sr.AddAttrs(slog.Any("error", r.Err))
+	}
+	for _, f := range r.Fields {
+		sr.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+	l.Handler.Handle(ctx, sr)
+}
+
+// toSlogLevel maps a LogLevel onto the slog level matching its severity
+// LogLevelFatal is reported as slog.LevelError+4 and LogLevelPanic as slog.LevelError+8
+func toSlogLevel(level LogLevel) slog.Level {
+	return slog.Level(levelSeverity(level))
+}
+
+// fromSlogLevel maps a slog level onto the registered level with the closest severity
+// at or below it, or the least severe level when every level is above it
+func fromSlogLevel(level slog.Level) LogLevel {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	var best, least LogLevel
+	found, first := false, true
+	for l, severity := range severities {
+		if first || compareLevels(l, least) < 0 {
+			least, first = l, false
+		}
+		if severity <= int(level) && (!found || compareLevels(l, best) > 0) {
+			best, found = l, true
+		}
+	}
+	if !found {
+		return least
+	}
+	return best
+}
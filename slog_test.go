@@ -0,0 +1,170 @@
+package logerr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := DefaultLogger().SetContext("API")
+	logger.Output = &buf
+	logger.Level = LogLevelInfo
+
+	slogger := slog.New(NewSlogHandler(logger))
+
+	// Test level mapping and filtering
+	slogger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug record to be filtered, got %q", buf.String())
+	}
+
+	slogger.Info("started", "port", 8080)
+	expected := "[INF] API | started port=8080\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	// Test that groups become context and attrs become fields
+	buf.Reset()
+	authLogger := slogger.WithGroup("Auth").With("user", "alice")
+	authLogger.Warn("retrying", slog.Group("req", "id", 7))
+	expected = "[WRN] API | Auth | retrying user=alice req.id=7\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	// Test that error attrs are recorded as the record error
+	buf.Reset()
+	logger.SetFormatter(JSONFormatter{})
+	slogger.Error("failed", "err", errors.New("boom"))
+	if !strings.Contains(buf.String(), `"level":"ERR"`) || !strings.Contains(buf.String(), `"error":{"msg":"boom"`) {
+		t.Errorf("Expected structured error in JSON output, got %q", buf.String())
+	}
+
+	// Test Exclusive semantics through Enabled
+	logger.Exclusive = true
+	logger.Level = LogLevelWarn
+	handler := NewSlogHandler(logger)
+	tests := []struct {
+		level    slog.Level
+		expected bool
+	}{
+		{slog.LevelDebug, false},
+		{slog.LevelInfo, false},
+		{slog.LevelWarn, true},
+		{slog.LevelWarn + 2, true},
+		{slog.LevelError, false},
+	}
+	for _, test := range tests {
+		if got := handler.Enabled(context.Background(), test.level); got != test.expected {
+			t.Errorf("Enabled(%v) with exclusive WRN returned %v, expected %v", test.level, got, test.expected)
+		}
+	}
+}
+
+func TestFromSlogLevel(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		expected LogLevel
+	}{
+		{slog.LevelDebug - 8, LogLevelTrace},
+		{slog.LevelDebug - 4, LogLevelTrace},
+		{slog.LevelDebug, LogLevelDebug},
+		{slog.LevelInfo, LogLevelInfo},
+		{slog.LevelInfo + 1, LogLevelSuccess},
+		{slog.LevelInfo + 2, LogLevelNotice},
+		{slog.LevelInfo + 3, LogLevelNotice},
+		{slog.LevelWarn, LogLevelWarn},
+		{slog.LevelError, LogLevelError},
+		{slog.LevelError + 6, LogLevelFatal},
+		{slog.LevelError + 8, LogLevelPanic},
+		{slog.LevelError + 12, LogLevelPanic},
+	}
+	for _, test := range tests {
+		if got := fromSlogLevel(test.level); got != test.expected {
+			t.Errorf("fromSlogLevel(%v) = %v, expected %v", test.level, got, test.expected)
+		}
+	}
+
+	// Test that the lookup, which runs on every slog call, does not allocate
+	if n := testing.AllocsPerRun(100, func() { fromSlogLevel(slog.LevelWarn) }); n != 0 {
+		t.Errorf("Expected fromSlogLevel not to allocate, got %v allocations", n)
+	}
+}
+
+func TestLoggerHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	logger := DefaultLogger().SetContext("API").SetHandler(handler)
+	logger.Level = LogLevelInfo
+
+	// Test that the logger's own level still applies
+	logger.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug message to be filtered by the logger, got %q", buf.String())
+	}
+
+	// Test that context and fields become attributes
+	sub := logger.Add("Auth")
+	sub = sub.With("user", "alice")
+	sub.Warn("token expired")
+	expected := "level=WARN msg=\"token expired\" context=\"[API Auth]\" user=alice\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	// Test that errors are passed as an attribute
+	buf.Reset()
+	logger.Error("request failed", errors.New("boom"))
+	expected = "level=ERROR msg=\"request failed boom\" context=[API] error=boom\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	// Test that the handler's level is respected
+	buf.Reset()
+	logger.SetHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))
+	logger.Warn("below handler level")
+	if buf.Len() != 0 {
+		t.Errorf("Expected warning to be filtered by the handler, got %q", buf.String())
+	}
+}
+
+func TestToSlogLevel(t *testing.T) {
+	tests := []struct {
+		level    LogLevel
+		expected slog.Level
+	}{
+		{LogLevelTrace, slog.LevelDebug - 4},
+		{LogLevelDebug, slog.LevelDebug},
+		{LogLevelInfo, slog.LevelInfo},
+		{LogLevelSuccess, slog.LevelInfo + 1},
+		{LogLevelNotice, slog.LevelInfo + 2},
+		{LogLevelWarn, slog.LevelWarn},
+		{LogLevelError, slog.LevelError},
+		{LogLevelFatal, slog.LevelError + 4},
+		{LogLevelPanic, slog.LevelError + 8},
+	}
+	for _, test := range tests {
+		if got := toSlogLevel(test.level); got != test.expected {
+			t.Errorf("toSlogLevel(%v) = %v, expected %v", test.level, got, test.expected)
+		}
+		if got := fromSlogLevel(toSlogLevel(test.level)); got != test.level {
+			t.Errorf("Expected %v to round-trip through slog, got %v", test.level, got)
+		}
+	}
+}